@@ -0,0 +1,417 @@
+package sqlcmp
+
+import "reflect"
+
+// ApplyFunc is invoked by Apply for each not nil node n before and/or after the node's children,
+// using a Cursor describing the current node and providing operations on it.
+// The return value of ApplyFunc controls the syntax tree traversal, see Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root, and calling pre and post for each node
+// as described below. Apply returns the syntax tree, possibly modified.
+//
+// If pre is not nil, it is called for each node before the node's children are traversed (pre-order).
+// If pre returns false, no children are traversed, and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false, post is called for each node
+// after its children are traversed (post-order). If post returns false, traversal is terminated and
+// Apply returns immediately.
+//
+// Only fields that refer to AST nodes are considered children, nil children are skipped.
+// Children are traversed in the same order as Walk does.
+//
+// For example, to replace integer literals with bind parameters:
+//
+//	stmt = sqlcmp.Apply(stmt, func(c *sqlcmp.Cursor) bool {
+//		if _, ok := c.Node().(*sqlcmp.IntegerLiteral); ok {
+//			c.Replace(&sqlcmp.Placeholder{Token: sqlcmp.Token{Type: sqlcmp.PLACEHOLDER, Literal: "?"}})
+//		}
+//		return true
+//	}, nil).(sqlcmp.Statement)
+func Apply(root Node, pre, post ApplyFunc) (result Node) {
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+	}()
+
+	a := &application{pre: pre, post: post}
+	result = root
+	applyField(a, nil, "", &result)
+
+	return result
+}
+
+var abort = new(int) // singleton, to signal termination of Apply
+
+// A Cursor describes a node encountered during Apply.
+// Information about the node and its parent is available from the Node, Parent, Name and Index methods.
+//
+// If p is a variable of type and value of the current parent node c.Parent(), and f is the field
+// identifier with name c.Name(), the following invariants hold:
+//
+//	p.f                     == c.Node()  if c.Index() <  0
+//	p.f[c.Index()]          == c.Node()  if c.Index() >= 0 and c.Row() < 0
+//	p.f[c.Row()][c.Index()] == c.Node()  if c.Row() >= 0
+//
+// The last one is for the fields with rows of nodes: Values of SQLInsertStatement and Sets of GroupingExp.
+//
+// The methods Replace, Delete, InsertBefore and InsertAfter can be used to change the AST
+// without disrupting Apply.
+type Cursor struct {
+	parent  Node
+	name    string
+	iter    *iterator // valid if the node is an element of a slice
+	row     int       // index of the row which contains the slice, -1 if the field is not a slice of rows
+	list    nodeSlice // the slice of the parent which contains the node
+	replace func(Node)
+	node    Node
+}
+
+// Node returns the current Node.
+func (c *Cursor) Node() Node { return c.node }
+
+// Parent returns the parent of the current Node, it is nil for the root.
+func (c *Cursor) Parent() Node { return c.parent }
+
+// Name returns the name of the parent Node field that contains the current Node like: Cond, Arguments.
+// If the parent is the root, Name returns the empty string.
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index >= 0 of the current Node in the slice of Nodes that contains it,
+// or a value < 0 if the current Node is not part of a slice.
+// The index of the current node changes if InsertBefore is called while processing the current node.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+
+	return -1
+}
+
+// Row reports the index >= 0 of the row that contains the current Node in the field with rows of nodes
+// like Values of SQLInsertStatement, or a value < 0 if the field is not a slice of rows.
+func (c *Cursor) Row() int {
+	if c.iter != nil {
+		return c.row
+	}
+
+	return -1
+}
+
+// Replace replaces the current Node with n. The replacement node is not walked by Apply.
+// Replace with nil clears the field, use Delete to remove the node from the slice.
+// Replace panics if n can not be stored in the field of the parent, e.g. a Statement in place of Expression.
+func (c *Cursor) Replace(n Node) {
+	if c.list != nil {
+		c.list.set(c.Index(), n)
+	} else {
+		c.replace(n)
+	}
+
+	c.node = n
+}
+
+// Delete deletes the current Node from its containing slice.
+// If the current Node is not part of a slice, Delete panics.
+func (c *Cursor) Delete() {
+	if c.list == nil {
+		panic("Delete node not contained in slice")
+	}
+
+	c.list.delete(c.Index())
+	c.iter.step--
+}
+
+// InsertAfter inserts n after the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertAfter panics. Apply does not walk n.
+func (c *Cursor) InsertAfter(n Node) {
+	if c.list == nil {
+		panic("InsertAfter node not contained in slice")
+	}
+
+	c.list.insert(c.Index()+1, n)
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertBefore panics. Apply will not walk n.
+func (c *Cursor) InsertBefore(n Node) {
+	if c.list == nil {
+		panic("InsertBefore node not contained in slice")
+	}
+
+	c.list.insert(c.Index(), n)
+	c.iter.index++
+}
+
+// nodeSlice the slice of nodes of the parent which is edited by Cursor.
+type nodeSlice interface {
+	set(i int, n Node)
+	insert(i int, n Node)
+	delete(i int)
+}
+
+type nodeList[T Node] struct {
+	list *[]T
+}
+
+func (l nodeList[T]) set(i int, n Node) {
+	(*l.list)[i] = nodeAs[T](n)
+}
+
+func (l nodeList[T]) insert(i int, n Node) {
+	var zero T
+
+	list := append(*l.list, zero)
+	copy(list[i+1:], list[i:])
+	list[i] = nodeAs[T](n)
+	*l.list = list
+}
+
+func (l nodeList[T]) delete(i int) {
+	list := *l.list
+	copy(list[i:], list[i+1:])
+	list[len(list)-1] = *new(T)
+	*l.list = list[:len(list)-1]
+}
+
+// nodeAs converts the node to the type of the field, nil becomes the zero value.
+func nodeAs[T Node](n Node) T {
+	if n == nil {
+		var zero T
+		return zero
+	}
+
+	return n.(T)
+}
+
+// isNil reports whether the node is nil interface or nil pointer.
+func isNil(n Node) bool {
+	if n == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(n)
+
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+type iterator struct {
+	index, step int
+}
+
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+// applyField applies to the node stored in the field of the parent.
+func applyField[T Node](a *application, parent Node, name string, ptr *T) {
+	replace := func(n Node) { *ptr = nodeAs[T](n) }
+
+	a.apply(Cursor{parent: parent, name: name, replace: replace, node: *ptr})
+}
+
+// applyList applies to nodes of the slice of the parent, the slice may be changed by the cursor.
+func applyList[T Node](a *application, parent Node, name string, list *[]T) {
+	applyRow(a, parent, name, -1, list)
+}
+
+// applyRows applies to nodes of each row of the parent, rows may be changed by the cursor but not their number.
+func applyRows[T Node](a *application, parent Node, name string, rows [][]T) {
+	for i := range rows {
+		applyRow(a, parent, name, i, &rows[i])
+	}
+}
+
+// applyRow applies to nodes of the slice which is the row of the parent or the whole field if row < 0.
+func applyRow[T Node](a *application, parent Node, name string, row int, list *[]T) {
+	saved := a.iter
+	a.iter.index = 0
+
+	for a.iter.index < len(*list) {
+		a.iter.step = 1
+		a.apply(Cursor{
+			parent: parent, name: name, iter: &a.iter, row: row, list: nodeList[T]{list}, node: (*list)[a.iter.index],
+		})
+		a.iter.index += a.iter.step
+	}
+
+	a.iter = saved
+}
+
+func (a *application) apply(cursor Cursor) {
+	if isNil(cursor.node) {
+		return
+	}
+
+	saved := a.cursor
+	a.cursor = cursor
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	// children of the original node are walked even if pre replaced it.
+	a.applyChildren(cursor.node)
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+// applyChildren applies to children of the node in the same order as Walk.
+//
+//nolint:funlen,gocyclo,cyclop
+func (a *application) applyChildren(node Node) {
+	switch n := node.(type) {
+	// Statements.
+
+	case *Program:
+		applyList(a, n, "Statements", &n.Statements)
+	case *LetStatement:
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Value", &n.Value)
+	case *ReturnStatement:
+		applyField(a, n, "ReturnValue", &n.ReturnValue)
+	case *ExpressionStatement:
+		applyField(a, n, "Expression", &n.Expression)
+	case *BlockStatement:
+		applyList(a, n, "Statements", &n.Statements)
+	case *SQLSelectStatement:
+		applyList(a, n, "DistinctOn", &n.DistinctOn)
+		applyList(a, n, "SQLSelectColumns", &n.SQLSelectColumns)
+		applyList(a, n, "From", &n.From)
+		applyField(a, n, "Sample", &n.Sample)
+		applyField(a, n, "SampleOffset", &n.SampleOffset)
+		applyList(a, n, "ArrayJoin", &n.ArrayJoin)
+		applyList(a, n, "Join", &n.Join)
+		applyList(a, n, "Prewhere", &n.Prewhere)
+		applyList(a, n, "Cond", &n.Cond)
+		applyList(a, n, "Group", &n.Group)
+		applyList(a, n, "Window", &n.Window)
+		applyList(a, n, "Order", &n.Order)
+		applyField(a, n, "LimitBy", &n.LimitBy)
+		applyField(a, n, "Limit", &n.Limit)
+		applyField(a, n, "Offset", &n.Offset)
+		applyList(a, n, "Settings", &n.Settings)
+		applyField(a, n, "Lock", &n.Lock)
+		applyList(a, n, "Bad", &n.Bad)
+	case *SQLInsertStatement:
+		applyField(a, n, "Table", &n.Table)
+		applyRows(a, n, "Values", n.Values)
+		applyField(a, n, "Select", &n.Select)
+		applyField(a, n, "OnConflict", &n.OnConflict)
+		applyField(a, n, "OnDuplicate", &n.OnDuplicate)
+		applyList(a, n, "Returning", &n.Returning)
+
+	// Clauses.
+
+	case *SQLColumnExp:
+		applyField(a, n, "Value", &n.Value)
+	case *SQLTableExp:
+		applyField(a, n, "Table", &n.Table)
+		applyList(a, n, "Hints", &n.Hints)
+	case *TableFunction:
+		applyList(a, n, "Arguments", &n.Arguments)
+	case *SQLJoinExp:
+		applyField(a, n, "Table", &n.Table)
+		applyList(a, n, "Cond", &n.Cond)
+	case *ArrayJoinExp:
+		applyList(a, n, "Items", &n.Items)
+	case *SQLOrderExp:
+		applyField(a, n, "Value", &n.Value)
+	case *GroupingExp:
+		applyRows(a, n, "Sets", n.Sets)
+	case *SQLWindowExp:
+		applyField(a, n, "Spec", &n.Spec)
+	case *LimitByExp:
+		applyField(a, n, "Limit", &n.Limit)
+		applyField(a, n, "Offset", &n.Offset)
+		applyList(a, n, "Columns", &n.Columns)
+	case *OnConflictExp:
+		applyList(a, n, "Target", &n.Target)
+		applyList(a, n, "Set", &n.Set)
+		applyList(a, n, "Cond", &n.Cond)
+	case *OnDuplicateKeyExp:
+		applyList(a, n, "Set", &n.Set)
+
+	// Expressions.
+
+	case *SQLCondition:
+		applyField(a, n, "Expression", &n.Expression)
+	case *PrefixExpression:
+		applyField(a, n, "Right", &n.Right)
+	case *InfixExpression:
+		applyField(a, n, "Left", &n.Left)
+		applyField(a, n, "Right", &n.Right)
+	case *BetweenExpression:
+		applyField(a, n, "Column", &n.Column)
+		applyField(a, n, "From", &n.From)
+		applyField(a, n, "To", &n.To)
+	case *InExpression:
+		applyField(a, n, "Column", &n.Column)
+		applyList(a, n, "Arguments", &n.Arguments)
+	case *TupleExpression:
+		applyList(a, n, "Elements", &n.Elements)
+	case *ArrayLiteral:
+		applyList(a, n, "Elements", &n.Elements)
+	case *IndexExpression:
+		applyField(a, n, "Left", &n.Left)
+		applyField(a, n, "Index", &n.Index)
+	case *DotExpression:
+		applyField(a, n, "Left", &n.Left)
+		applyField(a, n, "Right", &n.Right)
+	case *LambdaExpression:
+		applyField(a, n, "Body", &n.Body)
+	case *AliasExpression:
+		applyField(a, n, "Value", &n.Value)
+	case *CallExpression:
+		applyField(a, n, "Function", &n.Function)
+		applyList(a, n, "Arguments", &n.Arguments)
+		applyField(a, n, "Window", &n.Window)
+	case *WindowSpec:
+		applyList(a, n, "PartitionBy", &n.PartitionBy)
+		applyList(a, n, "OrderBy", &n.OrderBy)
+		applyField(a, n, "Frame", &n.Frame)
+	case *WindowFrame:
+		applyField(a, n, "Start", &n.Start)
+		applyField(a, n, "End", &n.End)
+	case *FrameBound:
+		applyField(a, n, "Offset", &n.Offset)
+	case *SQLSubSelectExpression:
+		applyField(a, n, "Select", &n.Select)
+	case *ExistsExpression:
+		applyField(a, n, "Select", &n.Select)
+	case *QuantifiedExpression:
+		applyField(a, n, "Select", &n.Select)
+	case *CastExpression:
+		applyField(a, n, "Value", &n.Value)
+	case *ExtractExpression:
+		applyField(a, n, "From", &n.From)
+	case *IntervalLiteral:
+		applyField(a, n, "Value", &n.Value)
+	case *IfExpression:
+		applyField(a, n, "Condition", &n.Condition)
+		applyField(a, n, "Consequence", &n.Consequence)
+		applyField(a, n, "Alternative", &n.Alternative)
+	case *FunctionLiteral:
+		applyList(a, n, "Parameters", &n.Parameters)
+		applyField(a, n, "Body", &n.Body)
+	case *SelectLiteral:
+		applyList(a, n, "Columns", &n.Columns)
+		applyField(a, n, "From", &n.From)
+		applyList(a, n, "Where", &n.Where)
+		applyField(a, n, "GroupBy", &n.GroupBy)
+		applyField(a, n, "OrderBy", &n.OrderBy)
+
+	default:
+		// Identifier, QualifiedName, IntegerLiteral, FloatLiteral, StringLiteral, Boolean, Placeholder,
+		// TypedLiteral, IndexHintExp, LockExp and BadExpression have no children.
+	}
+}
@@ -0,0 +1,238 @@
+package sqlcmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	placeholder := &Placeholder{Token: Token{Type: PLACEHOLDER, Literal: "?"}}
+
+	tests := []struct {
+		name          string
+		input         string
+		pre, post     ApplyFunc
+		expectedQuery string
+	}{
+		{
+			name:  "replace literals",
+			input: "select a from t where b = 1 and c in (2, 3) and d = (select max(e) from s where f > 4)",
+			pre: func(c *Cursor) bool {
+				if _, ok := c.Node().(*IntegerLiteral); ok {
+					c.Replace(placeholder)
+				}
+
+				return true
+			},
+			expectedQuery: "SELECT a FROM t WHERE (((b = ?) AND c IN (?, ?)) AND (d = (SELECT max(e) FROM s WHERE (f > ?))));",
+		},
+		{
+			name:  "drop predicate",
+			input: "select a from t where b = 1 and deleted_at is null and c = 2",
+			post: func(c *Cursor) bool {
+				exp, ok := c.Node().(*InfixExpression)
+				if !ok || exp.Operator != SQLAnd {
+					return true
+				}
+
+				if is, ok := exp.Right.(*InfixExpression); ok && is.Operator == SQLIs {
+					c.Replace(exp.Left)
+				}
+
+				return true
+			},
+			expectedQuery: "SELECT a FROM t WHERE ((b = 1) AND (c = 2));",
+		},
+		{
+			name:  "delete arguments",
+			input: "select concat(a, 'x', b, 'y') from t where id in (1, 2, 3)",
+			pre: func(c *Cursor) bool {
+				switch c.Node().(type) {
+				case *StringLiteral:
+					c.Delete()
+				case *IntegerLiteral:
+					if c.Index() != 0 {
+						c.Delete()
+					}
+				}
+
+				return true
+			},
+			expectedQuery: "SELECT concat(a, b) FROM t WHERE id IN (1);",
+		},
+		{
+			name:  "insert columns",
+			input: "select a, b from t",
+			pre: func(c *Cursor) bool {
+				if c.Name() != "SQLSelectColumns" {
+					return true
+				}
+
+				name := c.Node().String()
+				c.InsertBefore(&Identifier{Token: Token{Type: IDENT, Literal: "x"}, Value: "x_" + name})
+				c.InsertAfter(&Identifier{Token: Token{Type: IDENT, Literal: "y"}, Value: "y_" + name})
+
+				return true
+			},
+			expectedQuery: "SELECT x_a, a, y_a, x_b, b, y_b FROM t;",
+		},
+		{
+			name:  "rename tables",
+			input: "select * from users u join orders o on o.user_id = u.id where exists (select 1 from users)",
+			pre: func(c *Cursor) bool {
+				if _, ok := c.Parent().(*SQLTableExp); ok && c.Name() == "Table" && c.Node().String() == "users" {
+					c.Replace(&Identifier{Token: Token{Type: IDENT, Literal: "accounts"}, Value: "accounts"})
+				}
+
+				return true
+			},
+			expectedQuery: "SELECT * FROM accounts AS u JOIN orders AS o ON (o.user_id = u.id) WHERE EXISTS (SELECT 1 FROM accounts);",
+		},
+		{
+			name:  "skip sub queries",
+			input: "select a from t where b in (select 1 from s) and c = 2",
+			pre: func(c *Cursor) bool {
+				switch c.Node().(type) {
+				case *SQLSubSelectExpression:
+					return false
+				case *IntegerLiteral:
+					c.Replace(placeholder)
+				}
+
+				return true
+			},
+			expectedQuery: "SELECT a FROM t WHERE (b IN (SELECT 1 FROM s) AND (c = ?));",
+		},
+		{
+			name:  "terminate",
+			input: "select a from t where b = 1 and c = 2",
+			post: func(c *Cursor) bool {
+				if _, ok := c.Node().(*IntegerLiteral); ok {
+					c.Replace(placeholder)
+
+					return false
+				}
+
+				return true
+			},
+			expectedQuery: "SELECT a FROM t WHERE ((b = ?) AND (c = 2));",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewParser(NewLexer(tt.input))
+			stmt := p.parseSQLStatement()
+			checkParserErrors(t, p)
+
+			result := Apply(stmt, tt.pre, tt.post)
+			require.Equal(t, tt.expectedQuery, result.String())
+		})
+	}
+}
+
+func TestApplyRoot(t *testing.T) {
+	t.Parallel()
+
+	p := NewParser(NewLexer("select a from t"))
+	stmt := p.parseSQLStatement()
+	checkParserErrors(t, p)
+
+	other := &Identifier{Token: Token{Type: IDENT, Literal: "x"}, Value: "x"}
+
+	result := Apply(stmt, func(c *Cursor) bool {
+		if c.Parent() == nil {
+			require.Equal(t, "", c.Name())
+			require.Equal(t, -1, c.Index())
+			c.Replace(other)
+		}
+
+		return true
+	}, nil)
+
+	require.Same(t, other, result)
+
+	require.Panics(t, func() {
+		Apply(stmt, func(c *Cursor) bool {
+			if _, ok := c.Parent().(*SQLSelectStatement); ok {
+				c.Replace(&SQLSelectStatement{}) // a statement can not be a column
+			}
+
+			return true
+		}, nil)
+	})
+
+	require.Panics(t, func() {
+		Apply(stmt, func(c *Cursor) bool {
+			c.Delete()
+
+			return true
+		}, nil)
+	})
+
+	var visited int
+
+	notNil := func(c *Cursor) bool {
+		require.False(t, isNil(c.Node()))
+		visited++
+
+		return true
+	}
+	Apply(&InfixExpression{Left: (*QualifiedName)(nil), Operator: ASSIGN}, notNil, notNil)
+	require.Equal(t, 2, visited)
+}
+
+func TestApplyRows(t *testing.T) {
+	t.Parallel()
+
+	p := NewParser(NewLexer("insert into t (a, b) values (1, 2), (3, 4), (5, 6)"))
+	stmt := p.parseSQLStatement()
+	checkParserErrors(t, p)
+
+	insert, ok := stmt.(*SQLInsertStatement)
+	require.True(t, ok)
+
+	result := Apply(stmt, func(c *Cursor) bool {
+		if c.Name() != "Values" {
+			require.Equal(t, -1, c.Row())
+			return true
+		}
+
+		require.Same(t, insert, c.Parent())
+		require.Equal(t, insert.Values[c.Row()][c.Index()], c.Node())
+
+		switch {
+		case c.Row() == 1:
+			c.Replace(&Placeholder{Token: Token{Type: PLACEHOLDER, Literal: "?"}})
+		case c.Row() == 2 && c.Index() == 0:
+			c.InsertBefore(&IntegerLiteral{Token: Token{Type: INT, Literal: "0"}})
+		}
+
+		return true
+	}, nil)
+	require.Equal(t, "INSERT INTO t (a, b) VALUES (1, 2), (?, ?), (0, 5, 6);", result.String())
+
+	p = NewParser(NewLexer("select a from t group by grouping sets ((a, b), (c))"))
+	stmt = p.parseSQLStatement()
+	checkParserErrors(t, p)
+
+	var sets int
+
+	Apply(stmt, func(c *Cursor) bool {
+		if grouping, ok := c.Parent().(*GroupingExp); ok {
+			require.Equal(t, "Sets", c.Name())
+			require.Equal(t, grouping.Sets[c.Row()][c.Index()], c.Node())
+			sets++
+		}
+
+		return true
+	}, nil)
+	require.Equal(t, 3, sets)
+}
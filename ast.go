@@ -43,7 +43,9 @@ func (p *Program) TokenLiteral() string {
 func (p *Program) String() string {
 	var out bytes.Buffer
 	for _, s := range p.Statements {
-		out.WriteString(s.String())
+		if !isNil(s) {
+			out.WriteString(s.String())
+		}
 	}
 
 	return out.String()
@@ -62,10 +64,10 @@ func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString(ls.TokenLiteral() + " ")
-	out.WriteString(ls.Name.String())
+	out.WriteString(exprString(ls.Name))
 	out.WriteString(" = ")
 	if ls.Value != nil {
-		out.WriteString(ls.Value.String())
+		out.WriteString(exprString(ls.Value))
 	}
 	out.WriteString(";")
 	return out.String()
@@ -81,6 +83,64 @@ func (i *Identifier) expressionNode()      {}
 func (i *Identifier) TokenLiteral() string { return i.Token.Literal }
 func (i *Identifier) String() string       { return i.Value }
 
+// QualifiedName that structure represents a name of table or column, optionally qualified by schema or table
+// like: users, db.users, t.id, `db`.`users`.*. Identifier is left for names of functions and parameters.
+type QualifiedName struct {
+	Token  Token // the first token of the name
+	Parts  []string
+	Quoted []bool // the part was written in quotes like: `order`
+	Quote  string // the quote of quoted parts: " in PostgreSQL, empty for backticks of MySQL and ClickHouse
+}
+
+// Structcher quotes are not a part of the structure, so `order` and order are equal.
+func (qn *QualifiedName) Structcher() string { return strings.Join(qn.Parts, DOT.String()) }
+
+func (qn *QualifiedName) expressionNode()      {}
+func (qn *QualifiedName) TokenLiteral() string { return qn.Token.Literal }
+func (qn *QualifiedName) String() string       { return qn.Structcher() }
+
+// QuotedString prints the name with the quotes of the query like: `db`.`order`, String omits them like Structcher,
+// so `users` and users are printed and hashed the same.
+func (qn *QualifiedName) QuotedString() string {
+	quote := qn.Quote
+	if quote == "" {
+		quote = "`"
+	}
+
+	parts := make([]string, len(qn.Parts))
+	for i := range qn.Parts {
+		parts[i] = qn.Parts[i]
+		if i < len(qn.Quoted) && qn.Quoted[i] {
+			parts[i] = quote + strings.ReplaceAll(parts[i], quote, quote+quote) + quote
+		}
+	}
+
+	return strings.Join(parts, DOT.String())
+}
+
+// Name returns the last part of the name like: users for db.users.
+func (qn *QualifiedName) Name() string {
+	if len(qn.Parts) == 0 {
+		return ""
+	}
+
+	return qn.Parts[len(qn.Parts)-1]
+}
+
+// Qualifier returns the name without the last part like: db for db.users.
+func (qn *QualifiedName) Qualifier() []string {
+	if len(qn.Parts) == 0 {
+		return nil
+	}
+
+	return qn.Parts[:len(qn.Parts)-1]
+}
+
+func (qn *QualifiedName) add(tok Token) {
+	qn.Parts = append(qn.Parts, tok.Literal)
+	qn.Quoted = append(qn.Quoted, tok.Type == STRING || tok.Type == QIDENT)
+}
+
 // ReturnStatement todo.
 type ReturnStatement struct {
 	Token       Token // the 'return' token
@@ -94,7 +154,7 @@ func (rs *ReturnStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString(rs.TokenLiteral() + " ")
 	if rs.ReturnValue != nil {
-		out.WriteString(rs.ReturnValue.String())
+		out.WriteString(exprString(rs.ReturnValue))
 	}
 	out.WriteString(";")
 	return out.String()
@@ -103,26 +163,84 @@ func (rs *ReturnStatement) String() string {
 // SQLSelectStatement todo.
 type SQLSelectStatement struct {
 	Token            Token // the 'select' token
+	Distinct         bool
+	DistinctOn       []Expression // PostgreSQL DISTINCT ON (a, b)
+	Modifiers        []string     // MySQL SQL_CALC_FOUND_ROWS, SQL_NO_CACHE, ...
 	SQLSelectColumns []Expression
 	From             []Expression
 	Join             []Expression
 	Cond             []Expression
 	Order            []Expression
 	Group            []Expression
+	Window           []Expression // named windows: WINDOW w AS (...)
+	GroupModifier    TokenType    // WITH ROLLUP or WITH CUBE
+
+	Offset    Expression
+	Limit     Expression
+	LimitAll  bool // LIMIT ALL
+	LimitKind LimitKind
 
-	Offset Expression
-	Limit  Expression
+	// ClickHouse clauses.
+
+	Final        bool         // FROM t FINAL
+	Sample       Expression   // SAMPLE k
+	SampleOffset Expression   // SAMPLE k OFFSET m
+	ArrayJoin    []Expression // [LEFT] ARRAY JOIN arr AS a
+	Prewhere     []Expression
+	WithTotals   bool        // GROUP BY ... WITH TOTALS
+	LimitBy      *LimitByExp // LIMIT n BY columns
+	Settings     []Expression
+	Format       string
+
+	Lock *LockExp // FOR UPDATE, LOCK IN SHARE MODE
+
+	Bad []*BadExpression // clauses which were not parsed in the recovery mode, they are not printed
 }
 
+// LimitKind is the syntax used to write the row limit of a query.
+type LimitKind int
+
+const (
+	// LimitComma is LIMIT [offset,] count.
+	LimitComma LimitKind = iota
+	// LimitOffset is LIMIT count OFFSET offset.
+	LimitOffset
+	// LimitFetch is OFFSET offset ROWS FETCH FIRST count ROWS ONLY.
+	LimitFetch
+)
+
 func (rs *SQLSelectStatement) statementNode()       {}
 func (rs *SQLSelectStatement) TokenLiteral() string { return rs.Token.Literal }
 
-// func (rs *SQLSelectStatement) Structcher() string { }
+// Structcher returns the structure of the whole query with masked values.
+func (rs *SQLSelectStatement) Structcher() string {
+	var sb strings.Builder
+	writeStatement(&sb, rs, SegmentAll|SegmentOptional)
+
+	return sb.String()
+}
+
+// distinct returns the modifier of the select list like: DISTINCT, DISTINCT ON (a, b).
+func (rs *SQLSelectStatement) distinct(fn func(Expression) string) string {
+	if len(rs.DistinctOn) == 0 {
+		return SQLDistinct.String()
+	}
+
+	return SQLDistinct.String() + " " + SQLOn.String() + " (" + joinExpressions(rs.DistinctOn, fn) + ")"
+}
 
 func (rs *SQLSelectStatement) toString(skipSemicolon bool) string {
 	var out bytes.Buffer
 	out.WriteString(SQLSelect.String())
 
+	if rs.Distinct {
+		out.WriteString(" " + rs.distinct(exprString))
+	}
+
+	for _, modifier := range rs.Modifiers {
+		out.WriteString(" " + modifier)
+	}
+
 	if rs.SQLSelectColumns != nil {
 		for i := range rs.SQLSelectColumns {
 			if i != 0 {
@@ -130,30 +248,52 @@ func (rs *SQLSelectStatement) toString(skipSemicolon bool) string {
 			}
 			out.WriteString(" ")
 
-			out.WriteString(rs.SQLSelectColumns[i].String())
+			out.WriteString(exprString(rs.SQLSelectColumns[i]))
 		}
 	}
 
+	// joins which are followed by comma separated tables are printed in their place like: FROM a JOIN b ON ..., c.
+	joins := 0
+
 	if rs.From != nil {
 		out.WriteString(" " + SQLFrom.String())
 
 		for i := range rs.From {
+			for ; i != 0 && joins < len(rs.Join) && joinAfter(rs.Join[joins], len(rs.From)) <= i; joins++ {
+				out.WriteString(" " + exprString(rs.Join[joins]))
+			}
+
 			if i != 0 {
 				out.WriteString(",")
 			}
 
 			out.WriteString(" ")
-			out.WriteString(rs.From[i].String())
+			out.WriteString(exprString(rs.From[i]))
 		}
 	}
 
-	if rs.Join != nil {
-		for i := range rs.Join {
-			out.WriteString(" ")
-			out.WriteString(rs.Join[i].String())
+	if rs.Final {
+		out.WriteString(" " + SQLFinal.String())
+	}
+
+	if rs.Sample != nil {
+		out.WriteString(" " + SQLSample.String() + " " + exprString(rs.Sample))
+
+		if rs.SampleOffset != nil {
+			out.WriteString(" " + SQLOffset.String() + " " + exprString(rs.SampleOffset))
 		}
 	}
 
+	for i := range rs.ArrayJoin {
+		out.WriteString(" " + exprString(rs.ArrayJoin[i]))
+	}
+
+	for i := joins; i < len(rs.Join); i++ {
+		out.WriteString(" " + exprString(rs.Join[i]))
+	}
+
+	writeClause(&out, SQLPrewhere.String(), rs.Prewhere)
+
 	if rs.Cond != nil {
 		out.WriteString(" " + SQLWhere.String())
 
@@ -163,7 +303,7 @@ func (rs *SQLSelectStatement) toString(skipSemicolon bool) string {
 			}
 
 			out.WriteString(" ")
-			out.WriteString(rs.Cond[i].String())
+			out.WriteString(exprString(rs.Cond[i]))
 		}
 	}
 
@@ -176,7 +316,28 @@ func (rs *SQLSelectStatement) toString(skipSemicolon bool) string {
 			}
 
 			out.WriteString(" ")
-			out.WriteString(rs.Group[i].String())
+			out.WriteString(exprString(rs.Group[i]))
+		}
+
+		if rs.GroupModifier != "" {
+			out.WriteString(" " + SQLWith.String() + " " + rs.GroupModifier.String())
+		}
+
+		if rs.WithTotals {
+			out.WriteString(" " + SQLWith.String() + " " + SQLTotals.String())
+		}
+	}
+
+	if rs.Window != nil {
+		out.WriteString(" " + SQLWindow.String())
+
+		for i := range rs.Window {
+			if i != 0 {
+				out.WriteString(",")
+			}
+
+			out.WriteString(" ")
+			out.WriteString(exprString(rs.Window[i]))
 		}
 	}
 
@@ -189,16 +350,26 @@ func (rs *SQLSelectStatement) toString(skipSemicolon bool) string {
 			}
 
 			out.WriteString(" ")
-			out.WriteString(rs.Order[i].String())
+			out.WriteString(exprString(rs.Order[i]))
 		}
 	}
 
-	if rs.Limit != nil {
-		if rs.Offset != nil {
-			out.WriteString(" " + SQLLimit.String() + " " + rs.Offset.String() + ", " + rs.Limit.String())
-		} else {
-			out.WriteString(" " + SQLLimit.String() + " " + rs.Limit.String())
-		}
+	if rs.LimitBy != nil {
+		out.WriteString(" " + rs.LimitBy.String())
+	}
+
+	rs.writeLimit(&out)
+
+	if rs.Lock != nil {
+		out.WriteString(" " + rs.Lock.String())
+	}
+
+	if rs.Settings != nil {
+		out.WriteString(" " + SQLSettings.String() + " " + joinExpressions(rs.Settings, setting))
+	}
+
+	if rs.Format != "" {
+		out.WriteString(" " + SQLFormat.String() + " " + rs.Format)
 	}
 
 	if skipSemicolon {
@@ -208,10 +379,286 @@ func (rs *SQLSelectStatement) toString(skipSemicolon bool) string {
 	return out.String()
 }
 
+// joinAfter returns the number of tables of FROM before the join, all tables for unknown position.
+func joinAfter(exp Expression, tables int) int {
+	if join, ok := exp.(*SQLJoinExp); ok && join != nil && join.After > 0 && join.After < tables {
+		return join.After
+	}
+
+	return tables
+}
+
+func (rs *SQLSelectStatement) writeLimit(out *bytes.Buffer) {
+	switch rs.LimitKind {
+	case LimitFetch:
+		if rs.Offset != nil {
+			out.WriteString(" " + SQLOffset.String() + " " + exprString(rs.Offset) + " " + SQLRows.String())
+		}
+
+		out.WriteString(" " + SQLFetch.String() + " " + SQLFirst.String())
+		if rs.Limit != nil {
+			out.WriteString(" " + exprString(rs.Limit))
+		}
+		out.WriteString(" " + SQLRows.String() + " " + SQLOnly.String())
+	case LimitOffset:
+		if rs.LimitAll {
+			out.WriteString(" " + SQLLimit.String() + " " + SQLAll.String())
+		} else if rs.Limit != nil {
+			out.WriteString(" " + SQLLimit.String() + " " + exprString(rs.Limit))
+		}
+
+		if rs.Offset != nil {
+			out.WriteString(" " + SQLOffset.String() + " " + exprString(rs.Offset))
+		}
+	default:
+		if rs.LimitAll {
+			out.WriteString(" " + SQLLimit.String() + " " + SQLAll.String())
+		} else if rs.Limit != nil {
+			if rs.Offset != nil {
+				out.WriteString(" " + SQLLimit.String() + " " + exprString(rs.Offset) + ", " + exprString(rs.Limit))
+			} else {
+				out.WriteString(" " + SQLLimit.String() + " " + exprString(rs.Limit))
+			}
+		}
+	}
+}
+
 func (rs *SQLSelectStatement) String() string {
 	return rs.toString(true)
 }
 
+// writeClause writes the keyword and comma separated list of the clause, empty clause is skipped.
+func writeClause(out *bytes.Buffer, keyword string, list []Expression) {
+	if len(list) == 0 {
+		return
+	}
+
+	out.WriteString(" " + keyword + " ")
+	out.WriteString(joinExpressions(list, exprString))
+}
+
+// setting returns the item of SETTINGS clause like: max_threads = 8.
+func setting(exp Expression) string {
+	return assignment(exp, exprString)
+}
+
+// assignment returns the item of SETTINGS or SET clause without parentheses like: name = excluded.name.
+func assignment(exp Expression, fn func(Expression) string) string {
+	if ie, ok := exp.(*InfixExpression); ok && ie.Operator == ASSIGN {
+		return fn(ie.Left) + " " + ASSIGN.String() + " " + fn(ie.Right)
+	}
+
+	return fn(exp)
+}
+
+// SQLInsertStatement that structure represents INSERT statement like:
+// INSERT INTO t (a, b) VALUES (1, 2) ON CONFLICT (a) DO NOTHING RETURNING id.
+type SQLInsertStatement struct {
+	Token         Token // the 'insert' or 'replace' token
+	Replace       bool  // MySQL REPLACE INTO
+	Ignore        bool  // MySQL INSERT IGNORE
+	Table         Expression
+	Alias         string
+	Columns       []string
+	Values        [][]Expression
+	DefaultValues bool                // DEFAULT VALUES
+	Select        *SQLSelectStatement // INSERT INTO t SELECT ...
+	OnConflict    *OnConflictExp
+	OnDuplicate   *OnDuplicateKeyExp
+	Returning     []Expression
+}
+
+func (is *SQLInsertStatement) statementNode()       {}
+func (is *SQLInsertStatement) TokenLiteral() string { return is.Token.Literal }
+
+// Structcher returns the structure of the whole statement with masked values.
+func (is *SQLInsertStatement) Structcher() string {
+	var sb strings.Builder
+	writeInsertStatement(&sb, is, SegmentAll|SegmentOptional)
+
+	return sb.String()
+}
+
+func (is *SQLInsertStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString(is.verb() + " " + SQLInto.String() + " " + is.table())
+
+	if len(is.Columns) != 0 {
+		out.WriteString(" (" + strings.Join(is.Columns, ", ") + ")")
+	}
+
+	switch {
+	case is.DefaultValues:
+		out.WriteString(" " + SQLDefault.String() + " " + SQLValues.String())
+	case is.Select != nil:
+		out.WriteString(" " + is.Select.toString(false))
+	default:
+		out.WriteString(" " + SQLValues.String() + " " + strings.Join(is.rows(exprString), ", "))
+	}
+
+	if is.OnConflict != nil {
+		out.WriteString(" " + is.OnConflict.String())
+	}
+
+	if is.OnDuplicate != nil {
+		out.WriteString(" " + is.OnDuplicate.String())
+	}
+
+	writeClause(&out, SQLReturning.String(), is.Returning)
+	out.WriteString(";")
+
+	return out.String()
+}
+
+// verb returns the kind of the statement like: INSERT, INSERT IGNORE, REPLACE.
+func (is *SQLInsertStatement) verb() string {
+	verb := SQLInsert.String()
+	if is.Replace {
+		verb = SQLReplace.String()
+	}
+
+	if is.Ignore {
+		verb += " " + SQLIgnore.String()
+	}
+
+	return verb
+}
+
+// table returns the target table with alias like: users AS u.
+func (is *SQLInsertStatement) table() string {
+	return tableReference(exprString(is.Table), false, is.Alias, nil)
+}
+
+// rows returns the rows of VALUES clause like: (1, 2).
+func (is *SQLInsertStatement) rows(fn func(Expression) string) []string {
+	rows := make([]string, len(is.Values))
+	for i := range is.Values {
+		rows[i] = "(" + joinExpressions(is.Values[i], fn) + ")"
+	}
+
+	return rows
+}
+
+// OnDuplicateKeyExp that structure represents MySQL ON DUPLICATE KEY UPDATE clause like:
+// ON DUPLICATE KEY UPDATE hits = hits + 1, name = VALUES(name).
+type OnDuplicateKeyExp struct {
+	Token Token // the 'on' token
+	Set   []Expression
+}
+
+func (od *OnDuplicateKeyExp) Structcher() string {
+	return od.format(structcher)
+}
+
+func (od *OnDuplicateKeyExp) format(fn func(Expression) string) string {
+	return SQLOn.String() + " " + SQLDuplicate.String() + " " + SQLKey.String() + " " + SQLUpdate.String() + " " +
+		joinExpressions(od.Set, func(exp Expression) string { return assignment(exp, fn) })
+}
+
+func (od *OnDuplicateKeyExp) expressionNode()      {}
+func (od *OnDuplicateKeyExp) TokenLiteral() string { return od.Token.Literal }
+func (od *OnDuplicateKeyExp) String() string {
+	return od.format(exprString)
+}
+
+// OnConflictExp that structure represents PostgreSQL ON CONFLICT clause like:
+// ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE t.active.
+type OnConflictExp struct {
+	Token      Token        // the 'on' token
+	Target     []Expression // columns or expressions of the unique index
+	Constraint string       // ON CONFLICT ON CONSTRAINT name
+	Update     bool         // DO UPDATE, otherwise DO NOTHING
+	Set        []Expression
+	Cond       []Expression
+}
+
+func (oc *OnConflictExp) Structcher() string {
+	return oc.format(structcher)
+}
+
+func (oc *OnConflictExp) format(fn func(Expression) string) string {
+	str := SQLOn.String() + " " + SQLConflict.String()
+
+	switch {
+	case len(oc.Target) != 0:
+		str += " (" + joinExpressions(oc.Target, fn) + ")"
+	case oc.Constraint != "":
+		str += " " + SQLOn.String() + " " + SQLConstraint.String() + " " + oc.Constraint
+	}
+
+	if !oc.Update {
+		return str + " " + SQLDo.String() + " " + SQLNothing.String()
+	}
+
+	str += " " + SQLDo.String() + " " + SQLUpdate.String() + " " + SETS.String() + " "
+	str += joinExpressions(oc.Set, func(exp Expression) string { return assignment(exp, fn) })
+
+	if len(oc.Cond) != 0 {
+		str += " " + SQLWhere.String() + " " + joinExpressions(oc.Cond, fn)
+	}
+
+	return str
+}
+
+func (oc *OnConflictExp) expressionNode()      {}
+func (oc *OnConflictExp) TokenLiteral() string { return oc.Token.Literal }
+func (oc *OnConflictExp) String() string {
+	return oc.format(exprString)
+}
+
+// ArrayJoinExp that structure represents ClickHouse [LEFT] ARRAY JOIN arr AS a, arr2 AS b.
+type ArrayJoinExp struct {
+	Token Token
+	Left  bool
+	Items []Expression
+}
+
+func (aj *ArrayJoinExp) Structcher() string {
+	return aj.format(structcher)
+}
+
+func (aj *ArrayJoinExp) format(fn func(Expression) string) string {
+	str := SQLArray.String() + " " + SQLJoin.String() + " " + joinExpressions(aj.Items, fn)
+	if aj.Left {
+		return SQLLeft.String() + " " + str
+	}
+
+	return str
+}
+
+func (aj *ArrayJoinExp) expressionNode()      {}
+func (aj *ArrayJoinExp) TokenLiteral() string { return aj.Token.Literal }
+func (aj *ArrayJoinExp) String() string {
+	return aj.format(exprString)
+}
+
+// LimitByExp that structure represents ClickHouse LIMIT n [OFFSET m] BY columns.
+type LimitByExp struct {
+	Token   Token // the 'limit' token
+	Limit   Expression
+	Offset  Expression
+	Columns []Expression
+}
+
+func (lb *LimitByExp) Structcher() string {
+	return lb.format(structcher)
+}
+
+func (lb *LimitByExp) format(fn func(Expression) string) string {
+	str := SQLLimit.String() + " " + fn(lb.Limit)
+	if lb.Offset != nil {
+		str += " " + SQLOffset.String() + " " + fn(lb.Offset)
+	}
+
+	return str + " " + SQLBy.String() + " " + joinExpressions(lb.Columns, fn)
+}
+
+func (lb *LimitByExp) expressionNode()      {}
+func (lb *LimitByExp) TokenLiteral() string { return lb.Token.Literal }
+func (lb *LimitByExp) String() string {
+	return lb.format(exprString)
+}
+
 // ExpressionStatement todo.
 type ExpressionStatement struct {
 	Token      Token // the first token of the expression
@@ -223,7 +670,7 @@ func (es *ExpressionStatement) TokenLiteral() string { return es.Token.Literal }
 
 func (es *ExpressionStatement) String() string {
 	if es.Expression != nil {
-		return es.Expression.String()
+		return exprString(es.Expression)
 	}
 
 	return ""
@@ -239,6 +686,27 @@ func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
 func (il *IntegerLiteral) String() string       { return il.Token.Literal }
 
+// FloatLiteral numeric literal with fractional part like: 0.1.
+type FloatLiteral struct {
+	Token Token
+	Value float64
+}
+
+func (fl *FloatLiteral) Structcher() string   { return "?" }
+func (fl *FloatLiteral) expressionNode()      {}
+func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *FloatLiteral) String() string       { return fl.Token.Literal }
+
+// Placeholder bind parameter of prepared query like: ?, $1, :name.
+type Placeholder struct {
+	Token Token
+}
+
+func (pl *Placeholder) Structcher() string   { return "?" }
+func (pl *Placeholder) expressionNode()      {}
+func (pl *Placeholder) TokenLiteral() string { return pl.Token.Literal }
+func (pl *Placeholder) String() string       { return pl.Token.Literal }
+
 // PrefixExpression todo.
 type PrefixExpression struct {
 	Token    Token // The prefix token, e.g. !
@@ -246,13 +714,25 @@ type PrefixExpression struct {
 	Right    Expression
 }
 
+func (pe *PrefixExpression) Structcher() string {
+	return "(" + pe.operator() + structcher(pe.Right) + ")"
+}
+
+func (pe *PrefixExpression) operator() string {
+	if pe.Token.Type == SQLNot {
+		return pe.Operator + " "
+	}
+
+	return pe.Operator
+}
+
 func (pe *PrefixExpression) expressionNode()      {}
 func (pe *PrefixExpression) TokenLiteral() string { return pe.Token.Literal }
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
-	out.WriteString(pe.Operator)
-	out.WriteString(pe.Right.String())
+	out.WriteString(pe.operator())
+	out.WriteString(exprString(pe.Right))
 	out.WriteString(")")
 	return out.String()
 }
@@ -288,9 +768,13 @@ func infixStructcherFlat(node *InfixExpression) []string {
 		return flat
 	}
 
+	if isPathOperator(node.Operator) {
+		return append(flat, "("+structcher(node.Left)+" "+node.Operator.String()+" "+keyStructcher(node.Right)+")")
+	}
+
 	left := structcher(node.Left)
 	right := structcher(node.Right)
-	if _, ok := node.Right.(*Identifier); ok {
+	if isName(node.Right) {
 		flat = append(flat, "("+right+" "+node.Operator.String()+" "+left+")")
 	} else {
 		flat = append(flat, "("+left+" "+node.Operator.String()+" "+right+")")
@@ -319,17 +803,11 @@ func (oe *InfixExpression) TokenLiteral() string { return oe.Token.Literal }
 func (oe *InfixExpression) String() string {
 	var out bytes.Buffer
 
-	if oe.Operator != SQLAs {
-		out.WriteString("(")
-	}
-
-	out.WriteString(oe.Left.String())
+	out.WriteString("(")
+	out.WriteString(exprString(oe.Left))
 	out.WriteString(" " + oe.Operator.String() + " ")
-	out.WriteString(oe.Right.String())
-
-	if oe.Operator != SQLAs {
-		out.WriteString(")")
-	}
+	out.WriteString(exprString(oe.Right))
+	out.WriteString(")")
 
 	return out.String()
 }
@@ -357,9 +835,11 @@ func (ie *IfExpression) TokenLiteral() string { return ie.Token.Literal }
 func (ie *IfExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("if")
-	out.WriteString(ie.Condition.String())
+	out.WriteString(exprString(ie.Condition))
 	out.WriteString(" ")
-	out.WriteString(ie.Consequence.String())
+	if ie.Consequence != nil {
+		out.WriteString(ie.Consequence.String())
+	}
 	if ie.Alternative != nil {
 		out.WriteString("else ")
 		out.WriteString(ie.Alternative.String())
@@ -367,17 +847,19 @@ func (ie *IfExpression) String() string {
 	return out.String()
 }
 
+// BetweenExpression todo.
 type BetweenExpression struct {
-	Token  Token // The 'in' token
+	Token  Token // The 'between' token
 	Column Expression
 	From   Expression
 	To     Expression
+	Not    bool // NOT BETWEEN
 }
 
 func (ce *BetweenExpression) Structcher() string {
 	var out bytes.Buffer
-	out.WriteString(ce.Column.String() + " ")
-	out.WriteString(SQLBetween.String() + " ? AND ?")
+	out.WriteString(structcher(ce.Column) + " ")
+	out.WriteString(negate(SQLBetween, ce.Not) + " ? AND ?")
 
 	return out.String()
 }
@@ -386,11 +868,13 @@ func (ce *BetweenExpression) expressionNode()      {}
 func (ce *BetweenExpression) TokenLiteral() string { return ce.Token.Literal }
 func (ce *BetweenExpression) String() string {
 	var out bytes.Buffer
-	out.WriteString(ce.Column.String() + " ")
-	out.WriteString(SQLBetween.String() + " ")
-	out.WriteString(ce.From.String())
+	out.WriteString("(")
+	out.WriteString(exprString(ce.Column) + " ")
+	out.WriteString(negate(SQLBetween, ce.Not) + " ")
+	out.WriteString(exprString(ce.From))
 	out.WriteString(" AND ")
-	out.WriteString(ce.To.String())
+	out.WriteString(exprString(ce.To))
+	out.WriteString(")")
 
 	return out.String()
 }
@@ -400,27 +884,82 @@ type InExpression struct {
 	Token     Token // The 'in' token
 	Column    Expression
 	Arguments []Expression
+	Not       bool // NOT IN
 }
 
 func (ce *InExpression) Structcher() string {
 	var out bytes.Buffer
-	out.WriteString(ce.Column.String() + " ")
-	out.WriteString(SQLIn.String() + " ")
-	out.WriteString("(?)")
+	out.WriteString(structcher(ce.Column) + " ")
+	out.WriteString(negate(SQLIn, ce.Not) + " ")
+
+	switch sub := ce.subSelect(); {
+	case sub != nil:
+		out.WriteString(sub.Structcher())
+	case ce.tuple() != nil:
+		// a list of tuples collapses to the single tuple which keeps the arity.
+		out.WriteString("(" + ce.tuple().Structcher() + ")")
+	default:
+		out.WriteString("(?)")
+	}
 
 	return out.String()
 }
 
+// subSelect returns sub query when the expression is like: IN (select ...).
+func (ce *InExpression) subSelect() *SQLSubSelectExpression {
+	if len(ce.Arguments) != 1 {
+		return nil
+	}
+
+	sub, _ := ce.Arguments[0].(*SQLSubSelectExpression)
+
+	return sub
+}
+
+// tuple returns the first tuple when the expression is like: IN ((1, 2), (3, 4)).
+func (ce *InExpression) tuple() *TupleExpression {
+	if len(ce.Arguments) == 0 {
+		return nil
+	}
+
+	tuple, _ := ce.Arguments[0].(*TupleExpression)
+
+	return tuple
+}
+
+// TupleExpression that structure represents a row value like: (a, b).
+type TupleExpression struct {
+	Token    Token // the '(' token
+	Elements []Expression
+}
+
+func (te *TupleExpression) Structcher() string {
+	return "(" + joinExpressions(te.Elements, structcher) + ")"
+}
+
+func (te *TupleExpression) expressionNode()      {}
+func (te *TupleExpression) TokenLiteral() string { return te.Token.Literal }
+func (te *TupleExpression) String() string {
+	return "(" + joinExpressions(te.Elements, exprString) + ")"
+}
+
 func (ce *InExpression) expressionNode()      {}
 func (ce *InExpression) TokenLiteral() string { return ce.Token.Literal }
 func (ce *InExpression) String() string {
 	var out bytes.Buffer
 	var args []string
 	for _, a := range ce.Arguments {
-		args = append(args, a.String())
+		args = append(args, exprString(a))
+	}
+	out.WriteString(exprString(ce.Column) + " ")
+	out.WriteString(negate(SQLIn, ce.Not) + " ")
+
+	if sub := ce.subSelect(); sub != nil {
+		out.WriteString(sub.String())
+
+		return out.String()
 	}
-	out.WriteString(ce.Column.String() + " ")
-	out.WriteString(SQLIn.String() + " ")
+
 	out.WriteString("(")
 	out.WriteString(strings.Join(args, ", "))
 	out.WriteString(")")
@@ -439,7 +978,9 @@ func (bs *BlockStatement) TokenLiteral() string { return bs.Token.Literal }
 func (bs *BlockStatement) String() string {
 	var out bytes.Buffer
 	for _, s := range bs.Statements {
-		out.WriteString(s.String())
+		if !isNil(s) {
+			out.WriteString(s.String())
+		}
 	}
 	return out.String()
 }
@@ -457,13 +998,15 @@ func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
 	var params []string
 	for _, p := range fl.Parameters {
-		params = append(params, p.String())
+		params = append(params, exprString(p))
 	}
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") ")
-	out.WriteString(fl.Body.String())
+	if fl.Body != nil {
+		out.WriteString(fl.Body.String())
+	}
 	return out.String()
 }
 
@@ -491,23 +1034,38 @@ type CallExpression struct {
 	Function Expression
 	// Identifier or FunctionLiteral
 	Arguments []Expression
+	Window    *WindowSpec // analytic function: OVER (...)
 }
 
-func (ce *CallExpression) expressionNode()      {}
-func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
-func (ce *CallExpression) String() string {
+func (ce *CallExpression) Structcher() string {
+	return ce.format(structcher)
+}
+
+func (ce *CallExpression) format(fn func(Expression) string) string {
 	var out bytes.Buffer
 	args := []string{}
 	for _, a := range ce.Arguments {
-		args = append(args, a.String())
+		args = append(args, fn(a))
 	}
-	out.WriteString(ce.Function.String())
+	out.WriteString(exprString(ce.Function))
 	out.WriteString("(")
 	out.WriteString(strings.Join(args, ", "))
 	out.WriteString(")")
+
+	if ce.Window != nil {
+		out.WriteString(" " + SQLOver.String() + " ")
+		out.WriteString(fn(ce.Window))
+	}
+
 	return out.String()
 }
 
+func (ce *CallExpression) expressionNode()      {}
+func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *CallExpression) String() string {
+	return ce.format(exprString)
+}
+
 // StringLiteral todo.
 type StringLiteral struct {
 	Token Token
@@ -518,66 +1076,297 @@ func (sl *StringLiteral) expressionNode()      {}
 func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
 func (sl *StringLiteral) String() string       { return sl.Token.Literal }
 
-// SQLSource that structure represents representations of a different source in the DB.
-type SQLSource struct {
-	Token Token
-	Value string
+// SQLColumnExp that structure represents an item of select list with an alias like: count(*) AS cnt.
+type SQLColumnExp struct {
+	Token Token // the first token of the column
+	Value Expression
 	Alias string
 }
 
-func (sl *SQLSource) expressionNode()      {}
-func (sl *SQLSource) TokenLiteral() string { return sl.Token.Literal }
-func (sl *SQLSource) String() string {
-	if sl.Alias != "" {
-		return sl.Value + " AS " + sl.Alias
+// SQLSource an expression with an alias.
+//
+// Deprecated: use SQLColumnExp for expressions of the select list and GROUP BY, SQLTableExp for tables.
+type SQLSource = SQLColumnExp
+
+func (ce *SQLColumnExp) Structcher() string {
+	return structcher(ce.Value) + " " + SQLAs.String() + " " + ce.Alias
+}
+
+func (ce *SQLColumnExp) expressionNode()      {}
+func (ce *SQLColumnExp) TokenLiteral() string { return ce.Token.Literal }
+func (ce *SQLColumnExp) String() string {
+	return exprString(ce.Value) + " " + SQLAs.String() + " " + ce.Alias
+}
+
+// SQLTableExp that structure represents an item of FROM or JOIN clause:
+// a table name, a sub query or a table function with an optional alias.
+type SQLTableExp struct {
+	Token   Token
+	Table   Expression
+	Alias   string
+	Columns []string // column aliases like: AS t(a, b)
+	Lateral bool
+	Hints   []*IndexHintExp // MySQL USE INDEX (idx)
+}
+
+func (te *SQLTableExp) Structcher() string {
+	return te.format(structcher(te.Table))
+}
+
+func (te *SQLTableExp) format(table string) string {
+	table = tableReference(table, te.Lateral, te.Alias, te.Columns)
+	for _, hint := range te.Hints {
+		table += " " + hint.String()
+	}
+
+	return table
+}
+
+// tableReference returns the table with LATERAL prefix and alias like: LATERAL (select ...) AS t(a, b).
+func tableReference(table string, lateral bool, alias string, columns []string) string {
+	if lateral {
+		table = SQLLateral.String() + " " + table
+	}
+
+	if alias == "" {
+		return table
+	}
+
+	table += " " + SQLAs.String() + " " + alias
+	if len(columns) != 0 {
+		table += "(" + strings.Join(columns, ", ") + ")"
+	}
+
+	return table
+}
+
+func (te *SQLTableExp) expressionNode()      {}
+func (te *SQLTableExp) TokenLiteral() string { return te.Token.Literal }
+func (te *SQLTableExp) String() string       { return te.format(exprString(te.Table)) }
+
+// BadExpression that structure represents a clause of the query which could not be parsed.
+type BadExpression struct {
+	Token  Token     // the token where the error was found
+	Clause TokenType // the keyword of the clause: SELECT, FROM, WHERE, ... or ILLEGAL for unknown tokens
+	Tokens []Token   // skipped tokens of the clause
+	Errors []string
+}
+
+func (be *BadExpression) expressionNode()      {}
+func (be *BadExpression) TokenLiteral() string { return be.Token.Literal }
+func (be *BadExpression) String() string {
+	literals := make([]string, len(be.Tokens))
+	for i := range be.Tokens {
+		literals[i] = be.Tokens[i].Literal
+	}
+
+	return strings.Join(literals, " ")
+}
+
+// IndexHintExp that structure represents MySQL index hint like: USE INDEX FOR JOIN (idx_a, idx_b).
+type IndexHintExp struct {
+	Token   Token     // the USE, FORCE or IGNORE token
+	Type    TokenType // USE, FORCE or IGNORE
+	For     string    // JOIN, ORDER BY or GROUP BY
+	Indexes []string
+}
+
+func (ih *IndexHintExp) expressionNode()      {}
+func (ih *IndexHintExp) TokenLiteral() string { return ih.Token.Literal }
+func (ih *IndexHintExp) String() string {
+	str := ih.Type.String() + " " + SQLIndex.String()
+	if ih.For != "" {
+		str += " " + SQLFor.String() + " " + ih.For
+	}
+
+	return str + " (" + strings.Join(ih.Indexes, ", ") + ")"
+}
+
+// LockExp that structure represents locking clause like: FOR UPDATE OF t SKIP LOCKED, LOCK IN SHARE MODE.
+type LockExp struct {
+	Token     Token     // the FOR or LOCK token
+	Strength  TokenType // UPDATE or SHARE
+	ShareMode bool      // MySQL LOCK IN SHARE MODE
+	Of        []string
+	Wait      string // NOWAIT or SKIP LOCKED
+}
+
+func (le *LockExp) expressionNode()      {}
+func (le *LockExp) TokenLiteral() string { return le.Token.Literal }
+func (le *LockExp) String() string {
+	if le.ShareMode {
+		return SQLLock.String() + " " + SQLIn.String() + " " + SQLShare.String() + " " + SQLMode.String()
+	}
+
+	str := SQLFor.String() + " " + le.Strength.String()
+	if len(le.Of) != 0 {
+		str += " " + SQLOf.String() + " " + strings.Join(le.Of, ", ")
+	}
+
+	if le.Wait != "" {
+		str += " " + le.Wait
 	}
-	return sl.Value
+
+	return str
+}
+
+// TableFunction that structure represents table function in FROM or JOIN clause like: numbers(10),
+// the alias is stored in SQLTableExp.
+type TableFunction struct {
+	Token     Token // the name token
+	Name      string
+	Arguments []Expression
+}
+
+// Structcher literal arguments are masked, so remote('host1', db, t) and remote('host2', db, t) are equal.
+func (tf *TableFunction) Structcher() string {
+	return tf.format(structcher)
 }
 
-// SQLOrderExp todo.
+func (tf *TableFunction) format(fn func(Expression) string) string {
+	args := make([]string, 0, len(tf.Arguments))
+	for _, arg := range tf.Arguments {
+		args = append(args, fn(arg))
+	}
+
+	return tf.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (tf *TableFunction) expressionNode()      {}
+func (tf *TableFunction) TokenLiteral() string { return tf.Token.Literal }
+func (tf *TableFunction) String() string {
+	return tf.format(exprString)
+}
+
+// SQLOrderExp that structure represents an item of ORDER BY clause like: name COLLATE utf8_bin DESC NULLS LAST.
 type SQLOrderExp struct {
 	Token     Token
-	Value     string
+	Value     Expression
 	Direction Token
+	Nulls     TokenType // FIRST or LAST
+	Collate   string
+}
+
+func (sl *SQLOrderExp) Structcher() string {
+	return sl.format(positional)
+}
+
+func (sl *SQLOrderExp) format(fn func(Expression) string) string {
+	str := fn(sl.Value)
+	if sl.Collate != "" {
+		str += " " + SQLCollate.String() + " " + sl.Collate
+	}
+
+	if sl.Direction.Literal != "" {
+		str += " " + sl.Direction.Type.String()
+	}
+
+	if sl.Nulls != "" {
+		str += " " + SQLNulls.String() + " " + sl.Nulls.String()
+	}
+
+	return str
 }
 
 func (sl *SQLOrderExp) expressionNode()      {}
 func (sl *SQLOrderExp) TokenLiteral() string { return sl.Token.Literal }
 func (sl *SQLOrderExp) String() string {
-	if sl.Direction.Literal != "" {
-		return sl.Value + " " + sl.Direction.Type.String()
+	return sl.format(exprString)
+}
+
+// GroupingExp that structure represents an item of GROUP BY clause like:
+// ROLLUP (a, b), CUBE (a, b) or GROUPING SETS ((a, b), a, ()).
+type GroupingExp struct {
+	Token Token
+	Type  TokenType // ROLLUP, CUBE or GROUPING SETS
+	Sets  [][]Expression
+}
+
+func (ge *GroupingExp) Structcher() string {
+	return ge.format(positional)
+}
+
+func (ge *GroupingExp) format(fn func(Expression) string) string {
+	sets := make([]string, len(ge.Sets))
+	for i := range ge.Sets {
+		if len(ge.Sets[i]) == 1 {
+			sets[i] = fn(ge.Sets[i][0])
+		} else {
+			sets[i] = "(" + joinExpressions(ge.Sets[i], fn) + ")"
+		}
 	}
 
-	return sl.Value
+	return ge.Type.String() + " (" + strings.Join(sets, ", ") + ")"
+}
+
+func (ge *GroupingExp) expressionNode()      {}
+func (ge *GroupingExp) TokenLiteral() string { return ge.Token.Literal }
+func (ge *GroupingExp) String() string {
+	return ge.format(exprString)
 }
 
-// SQLJoinExp todo.
+// positional returns the structure of expression but keeps the column position like: GROUP BY 1.
+func positional(exp Expression) string {
+	if _, ok := exp.(*IntegerLiteral); ok {
+		return exp.String()
+	}
+
+	return structcher(exp)
+}
+
+// SQLJoinExp that structure represents a join of the table to the FROM clause.
 type SQLJoinExp struct {
-	Token Token
-	Type  TokenType
-	Table Expression
-	Cond  []Expression
+	Token      Token
+	Type       TokenType // kind: INNER, LEFT, RIGHT, FULL, CROSS or empty for plain JOIN
+	Strictness TokenType // ClickHouse strictness: ANY, ALL, ASOF, SEMI or ANTI
+	Natural    bool
+	Global     bool // ClickHouse GLOBAL JOIN
+	Table      Expression
+	Cond       []Expression // ON condition
+	Using      []string     // USING (a, b)
+	After      int          // the number of tables of FROM before the join, 0 is after all of them
 }
 
-func (sl *SQLJoinExp) expressionNode()      {}
-func (sl *SQLJoinExp) TokenLiteral() string { return sl.Token.Literal }
-func (sl *SQLJoinExp) String() string {
+func (sl *SQLJoinExp) Structcher() string {
+	return sl.format(structcher)
+}
+
+func (sl *SQLJoinExp) format(fn func(Expression) string) string {
 	str := ""
+	if sl.Global {
+		str += SQLGlobal.String() + " "
+	}
+	if sl.Natural {
+		str += SQLNatural.String() + " "
+	}
+	if sl.Strictness != "" {
+		str += sl.Strictness.String() + " "
+	}
 	if sl.Type.String() != "" {
-		str = sl.Type.String() + " "
+		str += sl.Type.String() + " "
 	}
 
-	str += SQLJoin.String() + " " + sl.Table.String()
+	str += SQLJoin.String() + " " + fn(sl.Table)
 	if sl.Cond != nil {
 		str += " ON "
 		for i := range sl.Cond {
-			str += sl.Cond[i].String()
+			str += fn(sl.Cond[i])
 		}
 	}
 
+	if sl.Using != nil {
+		str += " " + SQLUsing.String() + " (" + strings.Join(sl.Using, ", ") + ")"
+	}
+
 	return str
 }
 
+func (sl *SQLJoinExp) expressionNode()      {}
+func (sl *SQLJoinExp) TokenLiteral() string { return sl.Token.Literal }
+func (sl *SQLJoinExp) String() string {
+	return sl.format(exprString)
+}
+
 // SQLCondition wrapper for Expression.
 type SQLCondition struct {
 	Expression Expression
@@ -589,10 +1378,19 @@ func (sl *SQLCondition) Structcher() string {
 
 func (sl *SQLCondition) expressionNode()      {}
 func (sl *SQLCondition) TokenLiteral() string { return sl.Expression.TokenLiteral() }
-func (sl *SQLCondition) String() string       { return sl.Expression.String() }
+func (sl *SQLCondition) String() string       { return exprString(sl.Expression) }
+
+// exprString returns the string of the expression, nil expression is an empty string.
+func exprString(exp Expression) string {
+	if isNil(exp) {
+		return ""
+	}
+
+	return exp.String()
+}
 
 func structcher(exp Expression) string {
-	if exp == nil {
+	if isNil(exp) {
 		return ""
 	}
 
@@ -608,6 +1406,48 @@ func structcher(exp Expression) string {
 	return exp.String()
 }
 
+// isName the expression is a column name like: id, t.id.
+func isName(exp Expression) bool {
+	switch exp.(type) {
+	case *Identifier, *QualifiedName:
+		return true
+	}
+
+	return false
+}
+
+// isPathOperator the operator extracts JSON field by the key, the key is a part of the structure like: data->'user'.
+func isPathOperator(op TokenType) bool {
+	switch op {
+	case ARROW, LongArrow, HashArrow, HashLongArrow:
+		return true
+	}
+
+	return false
+}
+
+// keyStructcher returns the key of JSON path or the subscript as a part of the structure: string keys are quoted,
+// so data->'a' and data->a or m['a'] and m[a] differ, placeholders are masked like values.
+func keyStructcher(exp Expression) string {
+	switch tp := exp.(type) {
+	case *StringLiteral:
+		return "'" + strings.ReplaceAll(tp.Value, "'", "''") + "'"
+	case *IntegerLiteral:
+		return tp.String()
+	}
+
+	return structcher(exp)
+}
+
+// negate returns the keyword with NOT prefix when not is set.
+func negate(t TokenType, not bool) string {
+	if not {
+		return SQLNot.String() + " " + t.String()
+	}
+
+	return t.String()
+}
+
 // ArrayLiteral todo.
 type ArrayLiteral struct {
 	Token    Token // the '[' token
@@ -620,7 +1460,7 @@ func (al *ArrayLiteral) String() string {
 	var out bytes.Buffer
 	var elements []string
 	for _, el := range al.Elements {
-		elements = append(elements, el.String())
+		elements = append(elements, exprString(el))
 	}
 	out.WriteString("[")
 	out.WriteString(strings.Join(elements, ", "))
@@ -628,6 +1468,11 @@ func (al *ArrayLiteral) String() string {
 	return out.String()
 }
 
+// Structcher the whole array is masked as one value.
+func (al *ArrayLiteral) Structcher() string {
+	return "[?]"
+}
+
 // IndexExpression todo.
 type IndexExpression struct {
 	Token Token // The [ token
@@ -640,13 +1485,18 @@ func (ie *IndexExpression) TokenLiteral() string { return ie.Token.Literal }
 func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
-	out.WriteString(ie.Left.String())
+	out.WriteString(exprString(ie.Left))
 	out.WriteString("[")
-	out.WriteString(ie.Index.String())
+	out.WriteString(exprString(ie.Index))
 	out.WriteString("])")
 	return out.String()
 }
 
+// Structcher the literal subscript is a part of the structure like a column name, the placeholder is masked.
+func (ie *IndexExpression) Structcher() string {
+	return "(" + structcher(ie.Left) + "[" + keyStructcher(ie.Index) + "])"
+}
+
 // DotExpression todo.
 type DotExpression struct {
 	Token Token // The . token
@@ -658,24 +1508,348 @@ func (ie *DotExpression) expressionNode()      {}
 func (ie *DotExpression) TokenLiteral() string { return ie.Token.Literal }
 func (ie *DotExpression) String() string {
 	var out bytes.Buffer
-	out.WriteString(ie.Left.String())
+	out.WriteString(exprString(ie.Left))
 	out.WriteString(".")
-	out.WriteString(ie.Right.String())
+	out.WriteString(exprString(ie.Right))
 	return out.String()
 }
 
+// LambdaExpression ClickHouse lambda function like: x -> x * 2, (x, y) -> x + y.
+type LambdaExpression struct {
+	Token  Token // The -> token
+	Params []string
+	Body   Expression
+}
+
+func (le *LambdaExpression) expressionNode()      {}
+func (le *LambdaExpression) TokenLiteral() string { return le.Token.Literal }
+func (le *LambdaExpression) String() string {
+	return le.params() + " -> " + exprString(le.Body)
+}
+
+func (le *LambdaExpression) Structcher() string {
+	return le.params() + " -> " + structcher(le.Body)
+}
+
+func (le *LambdaExpression) params() string {
+	if len(le.Params) == 1 {
+		return le.Params[0]
+	}
+
+	return "(" + strings.Join(le.Params, ", ") + ")"
+}
+
+// AliasExpression ClickHouse alias inside an expression like: (a + 1 AS x) * 2.
+type AliasExpression struct {
+	Token Token // The 'as' token
+	Value Expression
+	Alias string
+}
+
+func (ae *AliasExpression) Structcher() string {
+	return ae.format(structcher(ae.Value))
+}
+
+func (ae *AliasExpression) format(value string) string {
+	return "(" + value + " " + SQLAs.String() + " " + ae.Alias + ")"
+}
+
+func (ae *AliasExpression) expressionNode()      {}
+func (ae *AliasExpression) TokenLiteral() string { return ae.Token.Literal }
+func (ae *AliasExpression) String() string       { return ae.format(exprString(ae.Value)) }
+
 // SQLSubSelectExpression todo.
 type SQLSubSelectExpression struct {
 	Token  Token // The ( token
 	Select *SQLSelectStatement
 }
 
+func (ie *SQLSubSelectExpression) Structcher() string {
+	if ie.Select == nil {
+		return "()"
+	}
+
+	return "(" + ie.Select.Structcher() + ")"
+}
+
 func (ie *SQLSubSelectExpression) expressionNode()      {}
 func (ie *SQLSubSelectExpression) TokenLiteral() string { return ie.Token.Literal }
 func (ie *SQLSubSelectExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
-	out.WriteString(ie.Select.toString(false))
+	if ie.Select != nil {
+		out.WriteString(ie.Select.toString(false))
+	}
 	out.WriteString(")")
 	return out.String()
 }
+
+// ExistsExpression sub query predicate like: EXISTS (select ...).
+type ExistsExpression struct {
+	Token  Token // The 'exists' token
+	Select *SQLSubSelectExpression
+	Not    bool // NOT EXISTS
+}
+
+func (ee *ExistsExpression) Structcher() string {
+	return negate(SQLExists, ee.Not) + " " + structcher(ee.Select)
+}
+
+func (ee *ExistsExpression) expressionNode()      {}
+func (ee *ExistsExpression) TokenLiteral() string { return ee.Token.Literal }
+func (ee *ExistsExpression) String() string {
+	return negate(SQLExists, ee.Not) + " " + exprString(ee.Select)
+}
+
+// QuantifiedExpression quantified sub query like: ANY (select ...), ALL (select ...).
+type QuantifiedExpression struct {
+	Token      Token // The quantifier token
+	Quantifier TokenType
+	Select     *SQLSubSelectExpression
+}
+
+func (qe *QuantifiedExpression) Structcher() string {
+	return qe.Quantifier.String() + " " + structcher(qe.Select)
+}
+
+func (qe *QuantifiedExpression) expressionNode()      {}
+func (qe *QuantifiedExpression) TokenLiteral() string { return qe.Token.Literal }
+func (qe *QuantifiedExpression) String() string {
+	return qe.Quantifier.String() + " " + exprString(qe.Select)
+}
+
+// WindowSpec window of analytic function like: OVER (PARTITION BY a ORDER BY b ROWS UNBOUNDED PRECEDING).
+type WindowSpec struct {
+	Token       Token  // The 'over' token or the name of the window
+	Name        string // reference to named window
+	PartitionBy []Expression
+	OrderBy     []Expression // list of *SQLOrderExp
+	Frame       *WindowFrame
+}
+
+// onlyName the window refers to named window like: OVER w.
+func (ws *WindowSpec) onlyName() bool {
+	return ws.Name != "" && len(ws.PartitionBy) == 0 && len(ws.OrderBy) == 0 && ws.Frame == nil
+}
+
+func (ws *WindowSpec) Structcher() string {
+	return ws.format(structcher)
+}
+
+func (ws *WindowSpec) format(fn func(Expression) string) string {
+	if ws.onlyName() {
+		return ws.Name
+	}
+
+	var parts []string
+	if ws.Name != "" {
+		parts = append(parts, ws.Name)
+	}
+
+	if len(ws.PartitionBy) != 0 {
+		parts = append(parts, SQLPartition.String()+" "+SQLBy.String()+" "+joinExpressions(ws.PartitionBy, fn))
+	}
+
+	if len(ws.OrderBy) != 0 {
+		parts = append(parts, SQLOrder.String()+" "+SQLBy.String()+" "+joinExpressions(ws.OrderBy, fn))
+	}
+
+	if ws.Frame != nil {
+		parts = append(parts, ws.Frame.format(fn))
+	}
+
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func (ws *WindowSpec) expressionNode()      {}
+func (ws *WindowSpec) TokenLiteral() string { return ws.Token.Literal }
+func (ws *WindowSpec) String() string {
+	return ws.format(exprString)
+}
+
+// WindowFrame frame clause of the window like: ROWS BETWEEN 1 PRECEDING AND CURRENT ROW.
+type WindowFrame struct {
+	Unit  TokenType // ROWS, RANGE or GROUPS
+	Start *FrameBound
+	End   *FrameBound // nil when the frame has only start
+}
+
+func (wf *WindowFrame) format(fn func(Expression) string) string {
+	if wf.End == nil {
+		return wf.Unit.String() + " " + wf.Start.format(fn)
+	}
+
+	return wf.Unit.String() + " " + SQLBetween.String() + " " + wf.Start.format(fn) + " " +
+		SQLAnd.String() + " " + wf.End.format(fn)
+}
+
+func (wf *WindowFrame) TokenLiteral() string { return wf.Unit.String() }
+func (wf *WindowFrame) String() string {
+	return wf.format(exprString)
+}
+
+// FrameBound bound of the window frame like: UNBOUNDED PRECEDING, CURRENT ROW, 1 FOLLOWING.
+type FrameBound struct {
+	Direction TokenType // PRECEDING, FOLLOWING or CURRENT for CURRENT ROW
+	Offset    Expression
+	Unbounded bool
+}
+
+func (fb *FrameBound) format(fn func(Expression) string) string {
+	switch {
+	case fb == nil:
+		return ""
+
+	case fb.Direction == SQLCurrent:
+		return SQLCurrent.String() + " " + SQLRow.String()
+	case fb.Unbounded:
+		return SQLUnbounded.String() + " " + fb.Direction.String()
+	case fb.Offset != nil:
+		return fn(fb.Offset) + " " + fb.Direction.String()
+	}
+
+	return fb.Direction.String()
+}
+
+func (fb *FrameBound) TokenLiteral() string { return fb.Direction.String() }
+func (fb *FrameBound) String() string {
+	return fb.format(exprString)
+}
+
+// SQLWindowExp named window of WINDOW clause like: w AS (PARTITION BY a).
+type SQLWindowExp struct {
+	Token Token // the name token
+	Name  string
+	Spec  *WindowSpec
+}
+
+func (we *SQLWindowExp) Structcher() string {
+	return we.format(structcher(we.Spec))
+}
+
+func (we *SQLWindowExp) format(spec string) string {
+	if we.Spec != nil && we.Spec.onlyName() {
+		spec = "(" + spec + ")"
+	}
+
+	return we.Name + " " + SQLAs.String() + " " + spec
+}
+
+func (we *SQLWindowExp) expressionNode()      {}
+func (we *SQLWindowExp) TokenLiteral() string { return we.Token.Literal }
+func (we *SQLWindowExp) String() string       { return we.format(exprString(we.Spec)) }
+
+func joinExpressions(list []Expression, fn func(Expression) string) string {
+	str := make([]string, len(list))
+	for i := range list {
+		str[i] = fn(list[i])
+	}
+
+	return strings.Join(str, ", ")
+}
+
+// CastSyntax is the syntax used to write the type conversion.
+type CastSyntax int
+
+const (
+	// CastFunction is CAST(x AS type).
+	CastFunction CastSyntax = iota
+	// CastOperator is x::type.
+	CastOperator
+	// CastConvert is CONVERT(x, type).
+	CastConvert
+	// CastConvertUsing is CONVERT(x USING charset).
+	CastConvertUsing
+	// CastTypeFunction is ClickHouse conversion function like toInt64(x).
+	CastTypeFunction
+)
+
+// CastExpression type conversion like: CAST(x AS Int64), x::int, CONVERT(x, CHAR), toInt64(x).
+type CastExpression struct {
+	Token  Token // The 'cast', 'convert', '::' or the conversion function token
+	Value  Expression
+	Type   string
+	Syntax CastSyntax
+}
+
+// Structcher the type conversion is written the same way regardless of the syntax.
+func (ce *CastExpression) Structcher() string {
+	if ce.Syntax == CastConvertUsing {
+		return ce.format(structcher(ce.Value))
+	}
+
+	return SQLCast.String() + "(" + structcher(ce.Value) + " " + SQLAs.String() + " " + ce.Type + ")"
+}
+
+func (ce *CastExpression) format(value string) string {
+	switch ce.Syntax {
+	case CastOperator:
+		return value + DOUBLECOLON.String() + ce.Type
+	case CastConvert:
+		return SQLConvert.String() + "(" + value + ", " + ce.Type + ")"
+	case CastConvertUsing:
+		return SQLConvert.String() + "(" + value + " " + SQLUsing.String() + " " + ce.Type + ")"
+	case CastTypeFunction:
+		return ce.Token.Literal + "(" + value + ")"
+	}
+
+	return SQLCast.String() + "(" + value + " " + SQLAs.String() + " " + ce.Type + ")"
+}
+
+func (ce *CastExpression) expressionNode()      {}
+func (ce *CastExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *CastExpression) String() string       { return ce.format(exprString(ce.Value)) }
+
+// ExtractExpression extracts part of the date like: EXTRACT(YEAR FROM ts).
+type ExtractExpression struct {
+	Token Token // The 'extract' token
+	Field string
+	From  Expression
+}
+
+func (ee *ExtractExpression) Structcher() string {
+	return ee.format(structcher(ee.From))
+}
+
+func (ee *ExtractExpression) format(from string) string {
+	return SQLExtract.String() + "(" + ee.Field + " " + SQLFrom.String() + " " + from + ")"
+}
+
+func (ee *ExtractExpression) expressionNode()      {}
+func (ee *ExtractExpression) TokenLiteral() string { return ee.Token.Literal }
+func (ee *ExtractExpression) String() string       { return ee.format(exprString(ee.From)) }
+
+// IntervalLiteral time interval like: INTERVAL '1' DAY, INTERVAL 1 DAY, INTERVAL '1 day'.
+type IntervalLiteral struct {
+	Token Token // The 'interval' token
+	Value Expression
+	Unit  string
+}
+
+func (il *IntervalLiteral) Structcher() string {
+	return il.format("?")
+}
+
+func (il *IntervalLiteral) format(value string) string {
+	if il.Unit == "" {
+		return SQLInterval.String() + " " + value
+	}
+
+	return SQLInterval.String() + " " + value + " " + il.Unit
+}
+
+func (il *IntervalLiteral) expressionNode()      {}
+func (il *IntervalLiteral) TokenLiteral() string { return il.Token.Literal }
+func (il *IntervalLiteral) String() string       { return il.format(exprString(il.Value)) }
+
+// TypedLiteral string literal with the type like: DATE '2023-09-27', TIMESTAMP '2023-09-27 10:00:00'.
+type TypedLiteral struct {
+	Token Token // The type token
+	Type  string
+	Value string
+}
+
+func (tl *TypedLiteral) Structcher() string   { return tl.Type + " ?" }
+func (tl *TypedLiteral) expressionNode()      {}
+func (tl *TypedLiteral) TokenLiteral() string { return tl.Token.Literal }
+func (tl *TypedLiteral) String() string       { return tl.Type + " " + tl.Value }
@@ -67,6 +67,44 @@ func TestSQLCondition_Structcher(t *testing.T) {
 			},
 			out: "t2.name IN (?)",
 		},
+		{
+			name: "infix not in",
+			in: SQLCondition{
+				Expression: &InExpression{
+					Column: &Identifier{Token: Token{Type: IDENT, Literal: "t2"}, Value: "t2.name"},
+					Arguments: []Expression{
+						&StringLiteral{Token: Token{Type: STRING, Literal: "abc"}, Value: "abc"},
+					},
+					Not: true,
+				},
+			},
+			out: "t2.name NOT IN (?)",
+		},
+		{
+			name: "call",
+			in: SQLCondition{
+				Expression: &CallExpression{
+					Function: &Identifier{Token: Token{Type: IDENT, Literal: "ifnull"}, Value: "ifnull"},
+					Arguments: []Expression{
+						&Identifier{Token: Token{Type: IDENT, Literal: "name"}, Value: "name"},
+						&StringLiteral{Token: Token{Type: STRING, Literal: "abc"}, Value: "abc"},
+					},
+				},
+			},
+			out: "ifnull(name, ?)",
+		},
+		{
+			name: "not between",
+			in: SQLCondition{
+				Expression: &BetweenExpression{
+					Column: &Identifier{Token: Token{Type: IDENT, Literal: "id"}, Value: "id"},
+					From:   &IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
+					To:     &IntegerLiteral{Token: Token{Type: INT, Literal: "2"}, Value: 2},
+					Not:    true,
+				},
+			},
+			out: "id NOT BETWEEN ? AND ?",
+		},
 	}
 
 	for i := range tt {
@@ -80,3 +118,161 @@ func TestSQLCondition_Structcher(t *testing.T) {
 		})
 	}
 }
+
+func TestNode_StringNilChildren(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		in   Node
+	}{
+		{
+			name: "prefix",
+			in:   &PrefixExpression{Operator: "-"},
+		},
+		{
+			name: "infix",
+			in:   &InfixExpression{Operator: ASSIGN},
+		},
+		{
+			name: "join",
+			in:   &SQLJoinExp{Type: SQLLeft},
+		},
+		{
+			name: "tuple",
+			in:   &TupleExpression{Elements: []Expression{nil, nil}},
+		},
+		{
+			name: "condition",
+			in:   &SQLCondition{},
+		},
+		{
+			name: "select",
+			in:   &SQLSelectStatement{SQLSelectColumns: []Expression{nil}, From: []Expression{nil}},
+		},
+		{
+			name: "typed nil children",
+			in: &InfixExpression{
+				Left:     (*SQLSubSelectExpression)(nil),
+				Operator: ASSIGN,
+				Right:    &ExistsExpression{Select: (*SQLSubSelectExpression)(nil)},
+			},
+		},
+		{
+			name: "window with typed nil spec",
+			in:   &SQLWindowExp{Name: "w", Spec: (*WindowSpec)(nil)},
+		},
+		{
+			name: "window frame with nil bounds",
+			in:   &WindowSpec{Frame: &WindowFrame{Unit: SQLRows}},
+		},
+		{
+			name: "let statement with typed nil name",
+			in:   &LetStatement{Name: (*Identifier)(nil)},
+		},
+		{name: "program", in: &Program{Statements: []Statement{nil}}},
+		{name: "let statement", in: &LetStatement{}},
+		{name: "identifier", in: &Identifier{}},
+		{name: "qualified name", in: &QualifiedName{}},
+		{name: "return statement", in: &ReturnStatement{}},
+		{name: "empty select", in: &SQLSelectStatement{}},
+		{name: "insert", in: &SQLInsertStatement{}},
+		{name: "on duplicate key", in: &OnDuplicateKeyExp{}},
+		{name: "on conflict", in: &OnConflictExp{}},
+		{name: "array join", in: &ArrayJoinExp{}},
+		{name: "limit by", in: &LimitByExp{}},
+		{name: "expression statement", in: &ExpressionStatement{}},
+		{name: "integer", in: &IntegerLiteral{}},
+		{name: "float", in: &FloatLiteral{}},
+		{name: "placeholder", in: &Placeholder{}},
+		{name: "empty prefix", in: &PrefixExpression{}},
+		{name: "empty infix", in: &InfixExpression{}},
+		{name: "boolean", in: &Boolean{}},
+		{name: "if", in: &IfExpression{}},
+		{name: "between", in: &BetweenExpression{}},
+		{name: "empty tuple", in: &TupleExpression{}},
+		{name: "in", in: &InExpression{}},
+		{name: "block", in: &BlockStatement{}},
+		{name: "function literal", in: &FunctionLiteral{}},
+		{name: "select literal", in: &SelectLiteral{}},
+		{name: "call", in: &CallExpression{}},
+		{name: "string", in: &StringLiteral{}},
+		{name: "column", in: &SQLColumnExp{}},
+		{name: "table", in: &SQLTableExp{}},
+		{name: "bad", in: &BadExpression{}},
+		{name: "index hint", in: &IndexHintExp{}},
+		{name: "lock", in: &LockExp{}},
+		{name: "table function", in: &TableFunction{}},
+		{name: "order", in: &SQLOrderExp{}},
+		{name: "grouping", in: &GroupingExp{}},
+		{name: "empty join", in: &SQLJoinExp{}},
+		{name: "array", in: &ArrayLiteral{}},
+		{name: "index", in: &IndexExpression{}},
+		{name: "dot", in: &DotExpression{}},
+		{name: "lambda", in: &LambdaExpression{}},
+		{name: "alias", in: &AliasExpression{}},
+		{name: "sub select", in: &SQLSubSelectExpression{}},
+		{name: "exists", in: &ExistsExpression{}},
+		{name: "quantified", in: &QuantifiedExpression{}},
+		{name: "window spec", in: &WindowSpec{}},
+		{name: "window frame", in: &WindowFrame{}},
+		{name: "frame bound", in: &FrameBound{}},
+		{name: "window", in: &SQLWindowExp{}},
+		{name: "cast", in: &CastExpression{}},
+		{name: "extract", in: &ExtractExpression{}},
+		{name: "interval", in: &IntervalLiteral{}},
+		{name: "typed literal", in: &TypedLiteral{}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.NotPanics(t, func() {
+				_ = tc.in.String()
+
+				if s, ok := tc.in.(SQLStructcher); ok {
+					_ = s.Structcher()
+				}
+			})
+		})
+	}
+}
+
+func TestQualifiedName_QuotedString(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		in     *QualifiedName
+		str    string
+		quoted string
+	}{
+		{
+			name:   "backticks",
+			in:     &QualifiedName{Parts: []string{"db", "order"}, Quoted: []bool{false, true}},
+			str:    "db.order",
+			quoted: "db.`order`",
+		},
+		{
+			name:   "double quotes",
+			in:     &QualifiedName{Parts: []string{"public", `my"t`}, Quoted: []bool{true, true}, Quote: `"`},
+			str:    `public.my"t`,
+			quoted: `"public"."my""t"`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.str, tc.in.String())
+			require.Equal(t, tc.str, tc.in.Structcher())
+			require.Equal(t, tc.quoted, tc.in.QuotedString())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"github.com/vench/sqlcmp"
 	"log"
@@ -27,8 +28,10 @@ func main() {
 	for scanner.Scan() {
 		query := scanner.Text()
 
-		hash, err := sqlcmp.SemiHash(query, sqlcmp.SegmentWhere|sqlcmp.SegmentFrom|sqlcmp.SegmentSkipValues)
-		if err != nil {
+		// queries which can not be parsed are grouped by their tokens
+		hash, err := sqlcmp.SemiHash(query, sqlcmp.SegmentWhere|sqlcmp.SegmentFrom|sqlcmp.SegmentSkipValues,
+			sqlcmp.WithTokenFallback())
+		if err != nil && !errors.Is(err, sqlcmp.ErrFallback) {
 			log.Fatalf("failed to maske hase: %v from query: %s\n", err, query)
 		}
 
@@ -0,0 +1,119 @@
+package sqlcmp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFallback the query was not parsed, the hash is TokenFingerprint of the query, see WithTokenFallback.
+var ErrFallback = errors.New("token fingerprint of unparsed query")
+
+// maskedTokens tokens which are replaced with ? by TokenFingerprint.
+var maskedTokens = map[TokenType]bool{
+	INT:         true,
+	FLOAT:       true,
+	STRING:      true,
+	PLACEHOLDER: true,
+}
+
+// fingerprintKeywords keywords of statements and clauses which are not parsed, but are upper cased by TokenFingerprint.
+var fingerprintKeywords = map[string]bool{
+	"create": true, "alter": true, "drop": true, "truncate": true, "delete": true, "table": true, "view": true,
+	"union": true, "intersect": true, "except": true, "having": true, "case": true, "when": true, "then": true,
+	"end": true, "null": true, "primary": true, "references": true, "show": true, "explain": true, "describe": true,
+	"begin": true, "commit": true, "rollback": true, "grant": true, "revoke": true,
+}
+
+// TokenFingerprint creates a hash of the query from its tokens only, so it works for queries which can not be parsed:
+// numbers, strings and placeholders are masked, lists of literals like IN (1, 2, 3) are collapsed to (?)
+// and keywords are upper cased.
+// The number of tokens is limited by WithMaxTokens.
+func TokenFingerprint(sql string, opts ...Option) (hash string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			hash, err = "", fmt.Errorf("%w: %v", ErrInternal, r)
+		}
+	}()
+
+	tokens, err := fingerprintTokens(sql, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return hashString(strings.Join(tokens, " "))
+}
+
+// fingerprintTokens returns normalized tokens of the query without the trailing semicolons.
+func fingerprintTokens(sql string, opts []Option) ([]string, error) {
+	var out []string
+
+	l := NewLexer(sql, opts...)
+	maxTokens := newConfig(opts).maxTokens
+
+	for tok, n := l.NextToken(), 1; tok.Type != EOF; tok, n = l.NextToken(), n+1 {
+		if maxTokens > 0 && n > maxTokens {
+			return nil, fmt.Errorf("%w: more than %d tokens", ErrLimitExceeded, maxTokens)
+		}
+
+		switch tok.Type {
+		case RPAREN, RBRACKET:
+			out = collapseList(out, tok.Literal)
+		default:
+			out = append(out, fingerprintToken(tok))
+		}
+	}
+
+	for len(out) != 0 && out[len(out)-1] == SEMICOLON.String() {
+		out = out[:len(out)-1]
+	}
+
+	return out, nil
+}
+
+// fingerprintToken returns the token masked or with normalized case of keyword.
+func fingerprintToken(tok Token) string {
+	switch {
+	case maskedTokens[tok.Type]:
+		return "?"
+	case tok.Type == IDENT:
+		if soft := LookupSoftKeyword(tok.Literal); soft != IDENT {
+			return soft.String()
+		}
+
+		if fingerprintKeywords[strings.ToLower(tok.Literal)] {
+			return strings.ToUpper(tok.Literal)
+		}
+
+		return tok.Literal
+	case tok.Type == QIDENT:
+		return tok.Literal
+	case LookupIdent(strings.ToLower(tok.Literal)) == tok.Type:
+		return tok.Type.String()
+	}
+
+	return tok.Literal
+}
+
+// collapseList closes the list, the list of masked literals like (?, ?, ?) is collapsed to (?)
+// and the same rows like VALUES (?), (?) are collapsed to the first one.
+func collapseList(out []string, closing string) []string {
+	i := len(out) - 1
+	for i > 0 && out[i] == "?" && out[i-1] == "," {
+		i -= 2
+	}
+
+	// not a list of literals
+	if i < 1 || out[i] != "?" || (out[i-1] != "(" && out[i-1] != "[") {
+		return append(out, closing)
+	}
+
+	out = append(out[:i+1], closing)
+
+	n := len(out)
+	if n >= 7 && closing == ")" && out[n-4] == "," && out[n-7] == "(" && out[n-6] == "?" && out[n-5] == closing {
+		return out[:n-4]
+	}
+
+	return out
+}
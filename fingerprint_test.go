@@ -0,0 +1,102 @@
+package sqlcmp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sql  string
+		opts []Option
+		out  string
+	}{
+		{
+			sql: "SELECT a FROM t WHERE id IN (1, 2, 3) and x = 'a';",
+			out: "SELECT a FROM t WHERE id IN ( ? ) AND x = ?",
+		},
+		{
+			sql: "insert into t (a, b) values (1, 'a'), (2, 'b'), (3, ?)",
+			out: "INSERT INTO t ( a , b ) VALUES ( ? )",
+		},
+		{
+			sql: "select f((1, 2)), arr[1], [1, 2.5] from t limit 10;;",
+			out: "SELECT f ( ( ? ) ) , arr [ ? ] , [ ? ] FROM t LIMIT ?",
+		},
+		{
+			sql: "create table x (id int primary key, name text default 'a')",
+			out: "CREATE TABLE x ( id int PRIMARY KEY , name text DEFAULT ? )",
+		},
+		{
+			sql:  `select "Name" from t where j ? 'k' and a = $1`,
+			opts: []Option{WithDialect(DialectPostgreSQL)},
+			out:  "SELECT Name FROM t WHERE j ? ? AND a = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		tokens, err := fingerprintTokens(tt.sql, tt.opts)
+		require.NoError(t, err)
+		require.Equal(t, tt.out, strings.Join(tokens, " "))
+
+		h, err := TokenFingerprint(tt.sql, tt.opts...)
+		require.NoError(t, err)
+		require.Equal(t, testHashString(t, tt.out), h)
+	}
+
+	h1, err := TokenFingerprint("with x as (select 1) select * from x where a in (1, 2)")
+	require.NoError(t, err)
+
+	h2, err := TokenFingerprint("WITH x AS (SELECT 5) SELECT * FROM x WHERE a IN (7)")
+	require.NoError(t, err)
+	require.Equal(t, h1, h2)
+}
+
+func TestSemiHashTokenFallback(t *testing.T) {
+	t.Parallel()
+
+	sql := "select a from t group x"
+
+	_, err := SemiHash(sql, SegmentAll)
+	require.True(t, errors.Is(err, ErrParse))
+
+	out, err := SemiHash(sql, SegmentAll, WithTokenFallback())
+	require.True(t, errors.Is(err, ErrFallback))
+	require.False(t, errors.Is(err, ErrParse))
+
+	h, err := TokenFingerprint(sql)
+	require.NoError(t, err)
+	require.Equal(t, h, out)
+
+	out, err = SemiHash("select a from t", SegmentAll, WithTokenFallback())
+	require.NoError(t, err)
+	require.Equal(t, testHashString(t, "a||t||"), out)
+}
+
+func TestSemiHashUnsupportedStatement(t *testing.T) {
+	t.Parallel()
+
+	segment := SegmentFrom | SegmentWhere | SegmentSkipValues
+
+	for _, sql := range []string{"DELETE FROM users WHERE id = 1", "UPDATE users SET a = 1 WHERE id = 1"} {
+		_, err := SemiHash(sql, segment)
+		require.Truef(t, errors.Is(err, ErrParse), "query: %s", sql)
+
+		out, err := SemiHash(sql, segment, WithTokenFallback())
+		require.Truef(t, errors.Is(err, ErrFallback), "query: %s", sql)
+
+		h, err := TokenFingerprint(sql)
+		require.NoError(t, err)
+		require.Equal(t, h, out)
+	}
+
+	del, _ := SemiHash("DELETE FROM users WHERE id = 1", segment, WithTokenFallback())
+	sel, err := SemiHash("SELECT * FROM users WHERE id = 7", segment, WithTokenFallback())
+	require.NoError(t, err)
+	require.NotEqual(t, sel, del)
+}
@@ -0,0 +1,97 @@
+package sqlcmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzSeeds queries of different syntax as the seed corpus of fuzz targets.
+var fuzzSeeds = []string{
+	"",
+	"select * from users where id = 1 and name like 'a%' order by id desc limit 10, 20",
+	"select a, count(*) as cnt from t join s on s.id = t.id group by a with rollup having",
+	"select * from t where (a, b) in ((1, 2), (3, 4)) and c between 1 and 2 and not exists (select 1)",
+	"select sum(x) over (partition by a order by b rows between unbounded preceding and current row) from t",
+	"select cast(a as int), extract(year from d), interval '1' day, date '2023-01-01' from t",
+	"select arrayMap(x -> x + 1, [1, 2]), t.1, m['k'] from t final sample 0.1 array join arr prewhere a = 1",
+	"select distinct on (a) \"A\"::text, j->'a'->>'b' from \"public\".\"T\" where j @> '{}' and j ? 'k'",
+	"insert into t (a, b) values (1, 'a'), (2, 'b') on conflict (a) do update set b = excluded.b returning *",
+	"replace into `t` (a) select a from s use index (i) for update skip locked",
+	"select * from numbers(10) as n(x), lateral (select 1) as l limit 1 by a settings max_threads = 1 format JSON",
+	"select a from t group x; select 1)); insert into",
+	"select ((((1 + ",
+	"select 'abc",
+}
+
+func FuzzSemiHash(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		for _, d := range []Dialect{DialectGeneric, DialectPostgreSQL, DialectMySQL} {
+			_, err := SemiHash(sql, SegmentAll, WithDialect(d), WithFunctionCasts())
+			require.NotErrorIs(t, err, ErrInternal)
+
+			_, err = SemiHash(sql, SegmentAll|SegmentSkipValues, WithDialect(d),
+				WithRecovery(), WithTruncation(), WithTokenFallback())
+			require.NotErrorIs(t, err, ErrInternal)
+		}
+	})
+}
+
+func FuzzParseScript(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		for _, d := range []Dialect{DialectGeneric, DialectPostgreSQL, DialectMySQL} {
+			for _, stmt := range ParseScript(sql, WithDialect(d), WithRecovery()) {
+				_ = stmt.Source(sql)
+				requireNoInternalErrors(t, stmt.Errors)
+
+				if stmt.Statement != nil {
+					_ = stmt.Statement.String()
+				}
+			}
+		}
+	})
+}
+
+func FuzzParseProgram(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		p := NewParser(NewLexer(sql))
+
+		program := p.ParseProgram()
+		_ = program.String()
+		requireNoInternalErrors(t, p.Errors())
+	})
+}
+
+func FuzzTokenFingerprint(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		for _, d := range []Dialect{DialectGeneric, DialectPostgreSQL, DialectMySQL} {
+			_, err := TokenFingerprint(sql, WithDialect(d))
+			require.NotErrorIs(t, err, ErrInternal)
+		}
+	})
+}
+
+// requireNoInternalErrors fails the fuzz target on the panic recovered by the parser.
+func requireNoInternalErrors(t *testing.T, errs []string) {
+	t.Helper()
+
+	for _, err := range errs {
+		require.NotContains(t, err, ErrInternal.Error())
+	}
+}
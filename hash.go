@@ -21,48 +21,366 @@ const (
 
 	SegmentSkipValues
 
-	SegmentAll = -1 ^ SegmentSkipValues
+	SegmentLimit // LIMIT, OFFSET and FETCH, it is not part of SegmentAll
+
+	// ClickHouse segments.
+
+	SegmentPrewhere
+	SegmentFinal
+	SegmentSample
+	SegmentArrayJoin
+	SegmentLimitBy
+	SegmentTotals
+	SegmentSettings // SETTINGS, it is not part of SegmentAll
+	SegmentFormat   // FORMAT, it is not part of SegmentAll
+
+	// INSERT segments.
+
+	SegmentValues // VALUES rows or SELECT which produces rows
+	SegmentUpsert // ON CONFLICT or ON DUPLICATE KEY UPDATE clause
+
+	// MySQL segments.
+
+	SegmentIndexHints // USE INDEX, FORCE INDEX and IGNORE INDEX of tables
+	SegmentLock       // FOR UPDATE, FOR SHARE and LOCK IN SHARE MODE
+
+	SegmentDistinct // DISTINCT and DISTINCT ON of SELECT
+
+	// SegmentOptional segments which are not part of SegmentAll, they have to be requested explicitly:
+	// SkipValues, Limit, Settings and Format. SETTINGS and FORMAT change the output of the query,
+	// not the query itself, like: SegmentAll | SegmentSettings | SegmentFormat.
+	SegmentOptional = SegmentSkipValues | SegmentLimit | SegmentSettings | SegmentFormat
+
+	SegmentAll = -1 ^ SegmentOptional
 )
 
 const delimiterSegment = "|"
 
 var ErrParse = errors.New("parse error")
 
+// ErrInternal the failure of the package which is returned instead of panic.
+var ErrInternal = errors.New("internal error")
+
+// ErrLimitExceeded the query exceeds the limits of nesting, the number of tokens or the length of lists,
+// see WithMaxDepth, WithMaxTokens and WithMaxListLength. The error is ErrParse too.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// PartialError the query was parsed partially with WithRecovery option,
+// the hash is created without the segments which were not parsed.
+type PartialError struct {
+	Segments Segment // segments which were not parsed
+	Errors   []string
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%s: partial query: %v", ErrParse, e.Errors)
+}
+
+func (e *PartialError) Unwrap() error {
+	return ErrParse
+}
+
+// clauseSegments segments of the query which are lost when the clause was not parsed.
+var clauseSegments = map[TokenType]Segment{
+	SQLSelect:   SegmentColumns | SegmentDistinct,
+	SQLFrom:     SegmentFrom | SegmentFinal | SegmentSample,
+	SQLArray:    SegmentArrayJoin,
+	SQLJoin:     SegmentJoin,
+	SQLPrewhere: SegmentPrewhere,
+	SQLWhere:    SegmentWhere,
+	SQLGroup:    SegmentGroup | SegmentTotals,
+	SQLWindow:   SegmentColumns,
+	SQLOrder:    SegmentOrder,
+	SQLLimit:    SegmentLimit | SegmentLimitBy,
+	SQLFor:      SegmentLock,
+	SQLSettings: SegmentSettings,
+	SQLFormat:   SegmentFormat,
+}
+
 // SemiHash this function creates a hash of a request based on its segment.
-func SemiHash(sql string, s Segment) (string, error) {
-	p := NewParser(NewLexer(sql))
+func SemiHash(sql string, s Segment, opts ...Option) (hash string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			hash, err = "", fmt.Errorf("%w: %v", ErrInternal, r)
+		}
+	}()
+
+	p := NewParser(NewLexer(sql, opts...), opts...)
 
-	stmt := p.parseSQLSelectStatement()
+	stmt := p.parseSQLStatement()
+
+	if p.limitExceeded {
+		return "", fmt.Errorf("%w: %w: %v", ErrParse, ErrLimitExceeded, p.Errors())
+	}
+
+	var (
+		partial   *PartialError
+		truncated bool
+	)
+
+	if p.config.truncation && (len(p.Errors()) != 0 || p.l.unterminated) {
+		if tp, tstmt, ok := parseTruncated(p, sql, opts); ok {
+			p, stmt, truncated = tp, tstmt, true
+		}
+	}
 
 	if errs := p.Errors(); len(errs) != 0 {
-		return "", fmt.Errorf("%w: %v", ErrParse, errs)
+		bad, ok := badSegments(stmt)
+		if !ok && p.config.tokenFallback {
+			return tokenFallback(sql, opts, errs)
+		}
+
+		if !ok {
+			return "", fmt.Errorf("%w: %v", ErrParse, errs)
+		}
+
+		partial = &PartialError{Segments: bad, Errors: errs}
+		s &^= bad
+	}
+
+	if stmt == nil {
+		return "", fmt.Errorf("%w: no statement", ErrParse)
 	}
 
 	var sb strings.Builder
 
+	switch stmt := stmt.(type) {
+	case *SQLSelectStatement:
+		writeStatement(&sb, stmt, s)
+	case *SQLInsertStatement:
+		writeInsertStatement(&sb, stmt, s)
+	}
+
+	// the hash of nothing collides for all broken queries
+	if partial != nil && sb.Len() == 0 {
+		return "", fmt.Errorf("%w: nothing is recovered: %v", ErrParse, partial.Errors)
+	}
+
+	h, err := hashString(sb.String())
+
+	switch {
+	case err != nil:
+		return "", err
+	case partial != nil:
+		return h, partial
+	case truncated:
+		return h, ErrTruncated
+	}
+
+	return h, nil
+}
+
+// tokenFallback returns TokenFingerprint of the query which can not be parsed.
+func tokenFallback(sql string, opts []Option, errs []string) (string, error) {
+	h, err := TokenFingerprint(sql, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return h, fmt.Errorf("%w: %v", ErrFallback, errs)
+}
+
+// badSegments returns segments of clauses which were not parsed in the recovery mode,
+// false if the statement was not recovered.
+func badSegments(stmt Statement) (Segment, bool) {
+	var bad []*BadExpression
+
+	switch stmt := stmt.(type) {
+	case *SQLSelectStatement:
+		bad = stmt.Bad
+	case *SQLInsertStatement:
+		if stmt.Select != nil && len(stmt.Select.Bad) != 0 {
+			return SegmentValues, true
+		}
+	}
+
+	var s Segment
+	for i := range bad {
+		s |= clauseSegments[bad[i].Clause]
+	}
+
+	return s, len(bad) != 0
+}
+
+// writeStatement writes segments of the query selected by mask.
+func writeStatement(sb *strings.Builder, stmt *SQLSelectStatement, s Segment) {
+	if s&SegmentDistinct != 0 && stmt.Distinct {
+		writeStrings(sb, []string{stmt.distinct(func(exp Expression) string { return segmentValue(exp, s) })})
+	}
+
 	if s&SegmentColumns != 0 {
-		writeSegment(&sb, stmt.SQLSelectColumns, s)
+		if len(stmt.Modifiers) != 0 {
+			writeStrings(sb, stmt.Modifiers)
+		}
+		writeSegment(sb, stmt.SQLSelectColumns, s)
+		writeSegment(sb, stmt.Window, s)
 	}
 
 	if s&SegmentFrom != 0 {
-		writeSegment(&sb, stmt.From, s)
+		writeSegment(sb, withoutHints(stmt.From), s)
 	}
 
 	if s&SegmentJoin != 0 {
-		writeSegment(&sb, stmt.Join, s)
+		writeSegment(sb, withoutHints(stmt.Join), s)
 	}
 
 	if s&SegmentWhere != 0 {
-		writeSegment(&sb, stmt.Cond, s)
+		writeSegment(sb, stmt.Cond, s)
 	}
 	if s&SegmentGroup != 0 {
-		writeSegment(&sb, stmt.Group, s)
+		writeStrings(sb, groupSegment(stmt, s))
 	}
 	if s&SegmentOrder != 0 {
-		writeSegment(&sb, stmt.Order, s)
+		writeSegment(sb, stmt.Order, s)
+	}
+	if s&SegmentLimit != 0 {
+		writeStrings(sb, limitSegment(stmt, s))
+	}
+	if s&SegmentIndexHints != 0 {
+		writeStrings(sb, indexHintsSegment(stmt, s))
+	}
+	if s&SegmentLock != 0 && stmt.Lock != nil {
+		writeStrings(sb, []string{stmt.Lock.String()})
+	}
+
+	writeClickHouseSegments(sb, stmt, s)
+}
+
+// withoutHints returns tables and joins without index hints, the hints are written to their own segment.
+func withoutHints(list []Expression) []Expression {
+	res := make([]Expression, len(list))
+
+	for i := range list {
+		res[i] = list[i]
+
+		switch exp := list[i].(type) {
+		case *SQLTableExp:
+			if len(exp.Hints) != 0 {
+				table := *exp
+				table.Hints = nil
+				res[i] = &table
+			}
+		case *SQLJoinExp:
+			if table, ok := exp.Table.(*SQLTableExp); ok && len(table.Hints) != 0 {
+				join := *exp
+				join.Table = withoutHints([]Expression{table})[0]
+				res[i] = &join
+			}
+		}
 	}
 
-	return hashString(sb.String())
+	return res
+}
+
+// indexHintsSegment returns index hints of tables of FROM and JOIN clauses like: users USE INDEX (idx_name),
+// the table is written the same way as in FROM and JOIN segments.
+func indexHintsSegment(stmt *SQLSelectStatement, s Segment) []string {
+	var str []string
+
+	tables := append([]Expression{}, stmt.From...)
+	for i := range stmt.Join {
+		if join, ok := stmt.Join[i].(*SQLJoinExp); ok {
+			tables = append(tables, join.Table)
+		}
+	}
+
+	for i := range tables {
+		table, ok := tables[i].(*SQLTableExp)
+		if !ok {
+			continue
+		}
+
+		for _, hint := range table.Hints {
+			str = append(str, segmentValue(table.Table, s)+" "+hint.String())
+		}
+	}
+
+	return str
+}
+
+// writeInsertStatement writes segments of INSERT statement selected by mask, the target table is FROM segment.
+func writeInsertStatement(sb *strings.Builder, stmt *SQLInsertStatement, s Segment) {
+	writeStrings(sb, []string{stmt.verb()})
+
+	if s&SegmentColumns != 0 {
+		if len(stmt.Columns) != 0 {
+			writeStrings(sb, []string{"(" + strings.Join(stmt.Columns, ", ") + ")"})
+		}
+		writeSegment(sb, stmt.Returning, s)
+	}
+	if s&SegmentFrom != 0 {
+		writeStrings(sb, []string{stmt.table()})
+	}
+	if s&SegmentValues != 0 {
+		writeStrings(sb, valuesSegment(stmt, s))
+	}
+	if s&SegmentUpsert != 0 && stmt.OnConflict != nil {
+		writeStrings(sb, []string{segmentValue(stmt.OnConflict, s)})
+	}
+	if s&SegmentUpsert != 0 && stmt.OnDuplicate != nil {
+		writeStrings(sb, []string{segmentValue(stmt.OnDuplicate, s)})
+	}
+}
+
+// valuesSegment returns the source of INSERT rows, with masked values the rows of the same shape are counted once.
+func valuesSegment(stmt *SQLInsertStatement, s Segment) []string {
+	switch {
+	case stmt.DefaultValues:
+		return []string{SQLDefault.String() + " " + SQLValues.String()}
+	case stmt.Select != nil && s&SegmentSkipValues != 0:
+		return []string{stmt.Select.Structcher()}
+	case stmt.Select != nil:
+		return []string{stmt.Select.toString(false)}
+	}
+
+	rows := stmt.rows(func(exp Expression) string { return segmentValue(exp, s) })
+	if s&SegmentSkipValues == 0 {
+		return rows
+	}
+
+	seen := make(map[string]bool, 1)
+	str := make([]string, 0, 1)
+
+	for i := range rows {
+		if !seen[rows[i]] {
+			seen[rows[i]] = true
+			str = append(str, rows[i])
+		}
+	}
+
+	return str
+}
+
+// writeClickHouseSegments writes segments of ClickHouse specific clauses selected by mask.
+func writeClickHouseSegments(sb *strings.Builder, stmt *SQLSelectStatement, s Segment) {
+	if s&SegmentPrewhere != 0 {
+		writeSegment(sb, stmt.Prewhere, s)
+	}
+	if s&SegmentFinal != 0 && stmt.Final {
+		writeStrings(sb, []string{SQLFinal.String()})
+	}
+	if s&SegmentSample != 0 && stmt.Sample != nil {
+		str := []string{SQLSample.String() + " " + segmentValue(stmt.Sample, s)}
+		if stmt.SampleOffset != nil {
+			str = append(str, SQLOffset.String()+" "+segmentValue(stmt.SampleOffset, s))
+		}
+		writeStrings(sb, str)
+	}
+	if s&SegmentArrayJoin != 0 {
+		writeSegment(sb, stmt.ArrayJoin, s)
+	}
+	if s&SegmentLimitBy != 0 && stmt.LimitBy != nil {
+		writeStrings(sb, []string{segmentValue(stmt.LimitBy, s)})
+	}
+	if s&SegmentTotals != 0 && stmt.WithTotals {
+		writeStrings(sb, []string{SQLWith.String() + " " + SQLTotals.String()})
+	}
+	if s&SegmentSettings != 0 {
+		writeSegment(sb, stmt.Settings, s)
+	}
+	if s&SegmentFormat != 0 && stmt.Format != "" {
+		writeStrings(sb, []string{SQLFormat.String() + " " + stmt.Format})
+	}
 }
 
 func hashString(s string) (string, error) {
@@ -77,17 +395,17 @@ func hashString(s string) (string, error) {
 }
 
 func writeSegment(b *strings.Builder, exp []Expression, s Segment) {
-	if len(exp) == 0 {
-		return
-	}
-
 	str := make([]string, len(exp))
 	for i := range exp {
-		if s&SegmentSkipValues != 0 {
-			str[i] = structcher(exp[i])
-		} else {
-			str[i] = exp[i].String()
-		}
+		str[i] = segmentValue(exp[i], s)
+	}
+
+	writeStrings(b, str)
+}
+
+func writeStrings(b *strings.Builder, str []string) {
+	if len(str) == 0 {
+		return
 	}
 
 	sort.SliceStable(str, func(i, j int) bool {
@@ -101,3 +419,46 @@ func writeSegment(b *strings.Builder, exp []Expression, s Segment) {
 
 	b.WriteString(delimiterSegment)
 }
+
+func segmentValue(exp Expression, s Segment) string {
+	if s&SegmentSkipValues != 0 {
+		return structcher(exp)
+	}
+
+	return exp.String()
+}
+
+// limitSegment returns the row limit regardless of the syntax it was written in.
+func limitSegment(stmt *SQLSelectStatement, s Segment) []string {
+	var str []string
+
+	if stmt.LimitAll {
+		str = append(str, SQLLimit.String()+" "+SQLAll.String())
+	} else if stmt.Limit != nil {
+		str = append(str, SQLLimit.String()+" "+segmentValue(stmt.Limit, s))
+	}
+
+	if stmt.Offset != nil {
+		str = append(str, SQLOffset.String()+" "+segmentValue(stmt.Offset, s))
+	}
+
+	return str
+}
+
+// groupSegment returns items of GROUP BY clause, positions of columns like GROUP BY 1 are not masked.
+func groupSegment(stmt *SQLSelectStatement, s Segment) []string {
+	str := make([]string, 0, len(stmt.Group)+1)
+	for i := range stmt.Group {
+		if s&SegmentSkipValues != 0 {
+			str = append(str, positional(stmt.Group[i]))
+		} else {
+			str = append(str, stmt.Group[i].String())
+		}
+	}
+
+	if stmt.GroupModifier != "" {
+		str = append(str, SQLWith.String()+" "+stmt.GroupModifier.String())
+	}
+
+	return str
+}
@@ -2,6 +2,7 @@ package sqlcmp
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,7 @@ func TestSemiHash(t *testing.T) {
 		sql     string
 		out     string
 		segment Segment
+		opts    []Option
 		err     error
 	}{
 		{
@@ -59,6 +61,278 @@ func TestSemiHash(t *testing.T) {
 			segment: SegmentAll,
 			out:     testHashString(t, "*||users||((id = 100) AND abc IN (99, 100))||"),
 		},
+		{
+			name:    "segment from and skip values with sub query",
+			sql:     "select * from (select id from users where id = 100 limit 1) as u",
+			segment: SegmentFrom | SegmentSkipValues,
+			out:     testHashString(t, "(id||users||(id = ?)||LIMIT ?||) AS u||"),
+		},
+		{
+			name:    "segment join and skip values with sub query",
+			sql:     "select * from t join (select id from users where age > 18) as u on u.id = t.id and u.type = 2",
+			segment: SegmentJoin | SegmentSkipValues,
+			out:     testHashString(t, "JOIN (id||users||(age > ?)||) AS u ON (t.id = u.id) AND(u.type = ?) AND||"),
+		},
+		{
+			name:    "segment join with strictness",
+			sql:     "select * from t left any join x using (id) full outer join y on y.id = t.id",
+			segment: SegmentJoin,
+			out:     testHashString(t, "FULL JOIN y ON (y.id = t.id)|ANY LEFT JOIN x USING (id)||"),
+		},
+		{
+			name:    "comma tables before join",
+			sql:     "select * from a, b join c on c.id = a.id",
+			segment: SegmentFrom | SegmentJoin,
+			out:     testHashString(t, "b|a||JOIN c ON (c.id = a.id)||"),
+		},
+		{
+			name:    "comma tables after join",
+			sql:     "select * from a join c on c.id = a.id, b",
+			segment: SegmentFrom | SegmentJoin,
+			out:     testHashString(t, "b|a||JOIN c ON (c.id = a.id)||"),
+		},
+		{
+			name:    "segment where and skip values with exists",
+			sql:     "select * from users where exists (select 1 from orders where orders.user_id = users.id and total > 100)",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "EXISTS (?||orders||(total > ?) AND(users.id = orders.user_id) AND||)||"),
+		},
+		{
+			name:    "segment where and skip values with or and precedence",
+			sql:     "select * from users where a = 1 or b = 2 and c = 3",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "(a = ?) OR(b = ?) AND OR(c = ?) AND OR||"),
+		},
+		{
+			name:    "segment where and skip values with grouped or",
+			sql:     "select * from users where (a = 1 or b = 2) and c = 3",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "(a = ?) OR(b = ?) OR AND(c = ?) AND||"),
+		},
+		{
+			name:    "segment where and skip values with in sub query",
+			sql:     "select * from users where id in (select user_id from orders where total > 100)",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "id IN (user_id||orders||(total > ?)||)||"),
+		},
+		{
+			name:    "segment columns and skip values with window",
+			sql:     "select sum(x) over (partition by a order by b rows 10 preceding) from t window w as (order by c)",
+			segment: SegmentColumns | SegmentSkipValues,
+			out:     testHashString(t, "sum(x) OVER (PARTITION BY a ORDER BY b ROWS ? PRECEDING)||w AS (ORDER BY c)||"),
+		},
+		{
+			name:    "segment where and skip values with casts",
+			sql:     "select * from t where id = cast('5' as Int64) and d > date '2020-01-01' - interval 1 day and x::int = 1",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "(CAST(x AS int) = ?) AND(d > (DATE ? - INTERVAL ? DAY)) AND AND(id = CAST(? AS Int64)) AND AND||"),
+		},
+		{
+			name:    "segment group and order skip values",
+			sql:     "select a from t group by toStartOfHour(ts), 1 with cube order by a + 1 desc, 2",
+			segment: SegmentGroup | SegmentOrder | SegmentSkipValues,
+			out:     testHashString(t, "toStartOfHour(ts)|WITH CUBE|1||2|(a + ?) DESC||"),
+		},
+		{
+			name:    "segment columns and from with implicit aliases",
+			sql:     "select count(*) cnt, 1 one from users u",
+			segment: SegmentColumns | SegmentFrom | SegmentSkipValues,
+			out:     testHashString(t, "count(*) AS cnt|? AS one||users AS u||"),
+		},
+		{
+			name:    "segment where and skip values with tuple in list",
+			sql:     "select * from t where (a, b) in ((1, 2), (3, 4), (5, 6))",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "(a, b) IN ((?, ?))||"),
+		},
+		{
+			name:    "segment where and skip values with short tuple in list",
+			sql:     "select * from t where (a, b) in ((1, 2))",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "(a, b) IN ((?, ?))||"),
+		},
+		{
+			name:    "segment prewhere is not merged into where",
+			sql:     "select * from t prewhere date = today() where id = 1",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "(id = ?)||"),
+		},
+		{
+			name:    "segment all without settings and format",
+			sql:     "select * from t final sample 0.1 where id = 1 limit 1 by id settings max_threads = 8 format JSON",
+			segment: SegmentAll | SegmentSkipValues,
+			out:     testHashString(t, "*||t||(id = ?)||FINAL||SAMPLE ?||LIMIT ? BY id||"),
+		},
+		{
+			name:    "segment all with settings and format",
+			sql:     "select * from t final sample 0.1 where id = 1 limit 1 by id settings max_threads = 8 format JSON",
+			segment: SegmentAll | SegmentSettings | SegmentFormat | SegmentSkipValues,
+			out: testHashString(t, "*||t||(id = ?)||FINAL||SAMPLE ?||LIMIT ? BY id||(max_threads = ?)||"+
+				"FORMAT JSON||"),
+		},
+		{
+			name:    "segment columns and where with lambdas, subscripts and arrays",
+			sql:     "select arrayMap(x -> x * 2, arr), t.1, m['key'] from t where has([1, 2, 3], id)",
+			segment: SegmentColumns | SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "t.1|arrayMap(x -> (x * ?), arr)|(m['key'])||has([?], id)||"),
+		},
+		{
+			name:    "segment from and join with table functions",
+			sql:     "select * from s3('https://bucket/a.csv') as a join remote('host-1', db, t) r on r.id = a.id",
+			segment: SegmentFrom | SegmentJoin | SegmentSkipValues,
+			out: testHashString(t, "s3(?) AS a||"+
+				"JOIN remote(?, db, t) AS r ON (a.id = r.id)||"),
+		},
+		{
+			name:    "segment from with table function keeps names of arguments",
+			sql:     "select * from remote('host-2', db, orders)",
+			segment: SegmentFrom | SegmentSkipValues,
+			out:     testHashString(t, "remote(?, db, orders)||"),
+		},
+		{
+			name:    "segment from with quoted qualified name",
+			sql:     "select `u`.* from `db`.`users` as `u`",
+			segment: SegmentColumns | SegmentFrom | SegmentSkipValues,
+			out:     testHashString(t, "u.*||db.users AS u||"),
+		},
+		{
+			name:    "postgresql json operators",
+			sql:     `select * from t where data @> '{"a": 1}' and data->'user'->>'id' = $1 and tags ?| ARRAY['x']`,
+			segment: SegmentWhere | SegmentSkipValues,
+			opts:    []Option{WithDialect(DialectPostgreSQL)},
+			out:     testHashString(t, "(((data -> 'user') ->> 'id') = ?) AND AND(data @> ?) AND AND(tags ?| [?]) AND||"),
+		},
+		{
+			name:    "backtick names",
+			sql:     "select `a` from t where `x` = 1",
+			segment: SegmentColumns | SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "a||(x = ?)||"),
+		},
+		{
+			name:    "mysql json operators",
+			sql:     "select data->'$.a' from t where data->>'$.id' = 1",
+			segment: SegmentColumns | SegmentWhere | SegmentSkipValues,
+			opts:    []Option{WithDialect(DialectMySQL)},
+			out:     testHashString(t, "(data -> '$.a')||((data ->> '$.id') = ?)||"),
+		},
+		{
+			name:    "postgresql distinct on",
+			sql:     `select distinct on ("user_id") "user_id", ts from events`,
+			segment: SegmentDistinct | SegmentColumns | SegmentFrom,
+			opts:    []Option{WithDialect(DialectPostgreSQL)},
+			out:     testHashString(t, "DISTINCT ON (user_id)||user_id|ts||events||"),
+		},
+		{
+			name:    "segment all with distinct",
+			sql:     "select distinct a from t",
+			segment: SegmentAll,
+			out:     testHashString(t, "DISTINCT||a||t||"),
+		},
+		{
+			name:    "segment all without distinct",
+			sql:     "select distinct a from t",
+			segment: SegmentAll &^ SegmentDistinct,
+			out:     testHashString(t, "a||t||"),
+		},
+		{
+			name:    "insert rows of the same shape are counted once",
+			sql:     "insert into users (id, name) values (1, 'a'), (2, 'b'), (3, 'c')",
+			segment: SegmentAll | SegmentSkipValues,
+			out:     testHashString(t, "INSERT||(id, name)||users||(?, ?)||"),
+		},
+		{
+			name:    "insert on conflict returning",
+			sql:     "insert into users (id) values ($1) on conflict (id) do update set hits = users.hits + 1 returning id",
+			segment: SegmentAll | SegmentSkipValues,
+			opts:    []Option{WithDialect(DialectPostgreSQL)},
+			out: testHashString(t, "INSERT||(id)||id||users||(?)||"+
+				"ON CONFLICT (id) DO UPDATE SET hits = (users.hits + ?)||"),
+		},
+		{
+			name:    "insert without upsert segment",
+			sql:     "insert into users (id) values (1) on conflict do nothing",
+			segment: SegmentFrom | SegmentValues,
+			out:     testHashString(t, "INSERT||users||(1)||"),
+		},
+		{
+			name:    "mysql index hints have own segment",
+			sql:     "select * from `users` u use index (idx_name) join orders o force index (PRIMARY) on o.uid = u.id",
+			segment: SegmentFrom | SegmentJoin | SegmentIndexHints,
+			opts:    []Option{WithDialect(DialectMySQL)},
+			out: testHashString(t, "users AS u||JOIN orders AS o ON (o.uid = u.id)||"+
+				"users USE INDEX (idx_name)|orders FORCE INDEX (PRIMARY)||"),
+		},
+		{
+			name:    "mysql index hints with skip values",
+			sql:     "select * from `db`.`users` u use index (idx_name) where u.id = 1",
+			segment: SegmentFrom | SegmentIndexHints | SegmentSkipValues,
+			opts:    []Option{WithDialect(DialectMySQL)},
+			out:     testHashString(t, "db.users AS u||db.users USE INDEX (idx_name)||"),
+		},
+		{
+			name:    "mysql index hints are skipped without segment",
+			sql:     "select * from `users` u use index (idx_name) join orders o force index (PRIMARY) on o.uid = u.id",
+			segment: SegmentFrom | SegmentJoin,
+			opts:    []Option{WithDialect(DialectMySQL)},
+			out:     testHashString(t, "users AS u||JOIN orders AS o ON (o.uid = u.id)||"),
+		},
+		{
+			name:    "mysql modifiers and locking",
+			sql:     "select sql_calc_found_rows id from users where id = 1 for update",
+			segment: SegmentColumns | SegmentLock | SegmentSkipValues,
+			opts:    []Option{WithDialect(DialectMySQL)},
+			out:     testHashString(t, "SQL_CALC_FOUND_ROWS||id||FOR UPDATE||"),
+		},
+		{
+			name:    "mysql on duplicate key update",
+			sql:     "insert ignore into users (id, hits) values (1, 1) on duplicate key update hits = hits + 1",
+			segment: SegmentAll | SegmentSkipValues,
+			opts:    []Option{WithDialect(DialectMySQL)},
+			out: testHashString(t, "INSERT IGNORE||(id, hits)||users||(?, ?)||"+
+				"ON DUPLICATE KEY UPDATE hits = (hits + ?)||"),
+		},
+		{
+			name:    "segment limit",
+			sql:     "select * from users limit 10 offset 20",
+			segment: SegmentLimit,
+			out:     testHashString(t, "OFFSET 20|LIMIT 10||"),
+		},
+		{
+			name:    "segment limit mysql syntax",
+			sql:     "select * from users limit 20, 10",
+			segment: SegmentLimit,
+			out:     testHashString(t, "OFFSET 20|LIMIT 10||"),
+		},
+		{
+			name:    "segment limit and skip values",
+			sql:     "select * from users offset 20 rows fetch first 10 rows only",
+			segment: SegmentLimit | SegmentSkipValues,
+			out:     testHashString(t, "OFFSET ?|LIMIT ?||"),
+		},
+		{
+			name:    "segment all without limit",
+			sql:     "select * from users limit 10 offset 20",
+			segment: SegmentAll,
+			out:     testHashString(t, "*||users||"),
+		},
+		{
+			name:    "segment from without limit",
+			sql:     "select * from users limit 10",
+			segment: SegmentFrom,
+			out:     testHashString(t, "users||"),
+		},
+		{
+			name:    "segment where and skip values with not in",
+			sql:     "select * from users where abc NOT IN (99,100)",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "abc NOT IN (?)||"),
+		},
+		{
+			name:    "segment where and skip values with not",
+			sql:     "select * from users where not name like 'a%'",
+			segment: SegmentWhere | SegmentSkipValues,
+			out:     testHashString(t, "(NOT (name LIKE ?))||"),
+		},
 	}
 
 	for i := range tt {
@@ -67,7 +341,7 @@ func TestSemiHash(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			out, err := SemiHash(tc.sql, tc.segment)
+			out, err := SemiHash(tc.sql, tc.segment, tc.opts...)
 			if tc.err == nil {
 				require.NoError(t, err)
 				require.EqualValues(t, tc.out, out)
@@ -78,3 +352,263 @@ func TestSemiHash(t *testing.T) {
 		})
 	}
 }
+
+func TestSemiHashQuotedNames(t *testing.T) {
+	t.Parallel()
+
+	for _, pair := range [][2]string{
+		{"select `a` from t", "select `b` from t"},
+		{"select a from t where `x` = 1", "select a from t where `y` = 1"},
+	} {
+		a, err := SemiHash(pair[0], SegmentAll|SegmentSkipValues)
+		require.NoError(t, err)
+
+		b, err := SemiHash(pair[1], SegmentAll|SegmentSkipValues)
+		require.NoError(t, err)
+
+		require.NotEqualf(t, a, b, "queries: %s, %s", pair[0], pair[1])
+	}
+
+	// quotes are not a part of the name with and without SegmentSkipValues.
+	for _, segment := range []Segment{SegmentAll, SegmentAll | SegmentSkipValues} {
+		a, err := SemiHash("select `id` from `db`.`users` where `id` = 1", segment)
+		require.NoError(t, err)
+
+		b, err := SemiHash("select id from db.users where id = 1", segment)
+		require.NoError(t, err)
+
+		require.Equal(t, a, b)
+	}
+}
+
+func TestSemiHashJSONPath(t *testing.T) {
+	t.Parallel()
+
+	hash := func(sql string) string {
+		h, err := SemiHash(sql, SegmentAll|SegmentSkipValues, WithDialect(DialectPostgreSQL))
+		require.NoError(t, err)
+
+		return h
+	}
+
+	require.Equal(t, hash("select data->$1 from t where data->>$1 = $2"), hash("select data->$2 from t where data->>$3 = $4"))
+	require.NotEqual(t, hash("select data->'a' from t"), hash("select data->a from t"))
+	require.NotEqual(t, hash("select data->'a' from t"), hash("select data->'b' from t"))
+}
+
+func TestSemiHashSubscript(t *testing.T) {
+	t.Parallel()
+
+	hash := func(sql string, opts ...Option) string {
+		h, err := SemiHash(sql, SegmentAll|SegmentSkipValues, opts...)
+		require.NoError(t, err)
+
+		return h
+	}
+
+	require.NotEqual(t, hash("select m['key'] from t"), hash("select m[key] from t"))
+	require.NotEqual(t, hash("select m['a'] from t"), hash("select m['b'] from t"))
+	require.Equal(t, hash("select arr[?] from t where arr[?] = 1"), hash("select arr[?] from t where arr[?] = 2"))
+	require.Equal(t, hash("select arr[$1] from t", WithDialect(DialectPostgreSQL)),
+		hash("select arr[$2] from t", WithDialect(DialectPostgreSQL)))
+	require.Equal(t, hash("select arr[?] from t"), hash("select arr[:idx] from t"))
+}
+
+func TestSemiHashRecovery(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		sql      string
+		out      string
+		segment  Segment
+		segments Segment
+	}{
+		{
+			name:     "bad group",
+			sql:      "select a from users group a where",
+			segment:  SegmentAll,
+			out:      testHashString(t, "a||users||"),
+			segments: SegmentGroup | SegmentTotals | SegmentWhere,
+		},
+		{
+			name:     "bad column does not skip from",
+			sql:      "select a, from t",
+			segment:  SegmentAll,
+			out:      testHashString(t, "t||"),
+			segments: SegmentColumns | SegmentDistinct,
+		},
+		{
+			name:     "bad sub query does not skip where",
+			sql:      "select a from (select b from t where x = ) s where y = 1",
+			segment:  SegmentAll | SegmentSkipValues,
+			out:      testHashString(t, "a||(y = ?)||"),
+			segments: SegmentFrom | SegmentFinal | SegmentSample,
+		},
+		{
+			name:     "bad join and order",
+			sql:      "select a from users join where id = 1 order by",
+			segment:  SegmentWhere | SegmentJoin | SegmentSkipValues,
+			out:      testHashString(t, "(id = ?)||"),
+			segments: SegmentJoin | SegmentOrder,
+		},
+		{
+			name:     "bad select of insert",
+			sql:      "insert into users (id) select id from s group id",
+			segment:  SegmentAll,
+			out:      testHashString(t, "INSERT||(id)||users||"),
+			segments: SegmentValues,
+		},
+	}
+
+	for i := range tt {
+		tc := tt[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := SemiHash(tc.sql, tc.segment, WithRecovery())
+			require.EqualValues(t, tc.out, out)
+
+			var partial *PartialError
+			require.True(t, errors.As(err, &partial))
+			require.True(t, errors.Is(err, ErrParse))
+			require.Equal(t, tc.segments, partial.Segments)
+			require.NotEmpty(t, partial.Errors)
+
+			_, err = SemiHash(tc.sql, tc.segment)
+			require.False(t, errors.As(err, &partial))
+			require.True(t, errors.Is(err, ErrParse))
+		})
+	}
+}
+
+func TestSemiHashRecoveryNothingRecovered(t *testing.T) {
+	t.Parallel()
+
+	out, err := SemiHash("select a, from t", SegmentColumns, WithRecovery())
+	require.Empty(t, out)
+	require.True(t, errors.Is(err, ErrParse))
+
+	var partial *PartialError
+	require.False(t, errors.As(err, &partial))
+}
+
+func TestSemiHashTruncation(t *testing.T) {
+	t.Parallel()
+
+	full, err := SemiHash("select * from logs where id in (1, 2, 3) and name = 'abc'", SegmentAll|SegmentSkipValues)
+	require.NoError(t, err)
+
+	out, err := SemiHash("select * from logs where id in (1, 2, 3) and name = 'ab", SegmentAll|SegmentSkipValues,
+		WithTruncation())
+	require.True(t, errors.Is(err, ErrTruncated))
+	require.Equal(t, full, out)
+
+	out, err = SemiHash("select * from logs where id in (1, 2, 3", SegmentAll|SegmentSkipValues, WithTruncation())
+	require.True(t, errors.Is(err, ErrTruncated))
+	require.Equal(t, testHashString(t, "*||logs||id IN (?)||"), out)
+
+	_, err = SemiHash("select * from logs where id = = 1 and", SegmentAll, WithTruncation())
+	require.False(t, errors.Is(err, ErrTruncated))
+	require.True(t, errors.Is(err, ErrParse))
+
+	out, err = SemiHash("select * from logs where id in (1, 2, 3) limit 1", SegmentAll, WithTruncation())
+	require.NoError(t, err)
+	require.NotEmpty(t, out)
+
+	_, err = SemiHash("select * from logs where id in (1, 2, 3", SegmentAll)
+	require.True(t, errors.Is(err, ErrParse))
+}
+
+func TestSemiHashLimits(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		sql  string
+		opts []Option
+	}{
+		{
+			name: "default depth of parens",
+			sql:  "select * from t where " + strings.Repeat("(", 100000) + "1" + strings.Repeat(")", 100000),
+		},
+		{
+			name: "default depth of operator chain",
+			sql:  "select " + strings.Repeat("1 + ", 100000) + "1",
+		},
+		{
+			name: "default depth of condition chain",
+			sql:  "select * from t where x = 1" + strings.Repeat(" = 1", 100000),
+		},
+		{
+			name: "default depth of postfix chains",
+			sql: "select a" + strings.Repeat("::int", 100000) + strings.Repeat("[1]", 100000) +
+				" from t where a" + strings.Repeat(" is not null", 100000),
+		},
+		{
+			name: "default depth of between chain",
+			sql:  "select * from t where a" + strings.Repeat(" between 1 and 2", 100000),
+		},
+		{
+			name: "default list length",
+			sql:  "select * from t where a in (" + strings.Repeat("1, ", DefaultMaxListLength) + "1)",
+		},
+		{
+			name: "depth of prefix operators",
+			sql:  "select " + strings.Repeat("-", 50) + "1",
+			opts: []Option{WithMaxDepth(10)},
+		},
+		{
+			name: "depth of sub queries",
+			sql:  "select * from " + strings.Repeat("(select * from ", 20) + "t" + strings.Repeat(")", 20),
+			opts: []Option{WithMaxDepth(10)},
+		},
+		{
+			name: "tokens",
+			sql:  "select a, b, c from t where a = 1",
+			opts: []Option{WithMaxTokens(5)},
+		},
+		{
+			name: "in list",
+			sql:  "select * from t where a in (" + strings.Repeat("1, ", 100) + "1)",
+			opts: []Option{WithMaxListLength(10)},
+		},
+		{
+			name: "values rows",
+			sql:  "insert into t values " + strings.Repeat("(1), ", 100) + "(1)",
+			opts: []Option{WithMaxListLength(10)},
+		},
+		{
+			name: "limit is not hidden by recovery and fallback",
+			sql:  "select * from t where a in (1, 2, 3) group x",
+			opts: []Option{WithMaxListLength(2), WithRecovery(), WithTruncation(), WithTokenFallback()},
+		},
+	}
+
+	for i := range tt {
+		tc := tt[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := SemiHash(tc.sql, SegmentAll, tc.opts...)
+			require.Empty(t, out)
+			require.True(t, errors.Is(err, ErrLimitExceeded))
+			require.True(t, errors.Is(err, ErrParse))
+		})
+	}
+
+	_, err := SemiHash("select * from t where a in (1, 2, 3)", SegmentAll, WithMaxListLength(3), WithMaxTokens(14))
+	require.NoError(t, err)
+
+	_, err = SemiHash("select "+strings.Repeat("(select ", 5000), SegmentAll)
+	require.True(t, errors.Is(err, ErrLimitExceeded))
+	require.Less(t, len(err.Error()), 200, "only the error of the limit is reported")
+
+	_, err = TokenFingerprint("select a, b, c from t", WithMaxTokens(5))
+	require.True(t, errors.Is(err, ErrLimitExceeded))
+
+	_, err = TokenFingerprint(strings.Repeat("a ", DefaultMaxTokens+1))
+	require.True(t, errors.Is(err, ErrLimitExceeded))
+}
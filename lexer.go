@@ -1,14 +1,18 @@
 package sqlcmp
 
+import "strings"
+
 type Lexer struct {
 	input        string
 	position     int
 	readPosition int
 	ch           byte // current char under examination
+	dialect      Dialect
+	unterminated bool // the input ends inside of a string or a quoted identifier
 }
 
-func NewLexer(input string) *Lexer {
-	l := &Lexer{input: input}
+func NewLexer(input string, opts ...Option) *Lexer {
+	l := &Lexer{input: input, dialect: newConfig(opts).dialect}
 	l.readChar()
 	return l
 }
@@ -29,6 +33,12 @@ func (l *Lexer) NextToken() Token {
 
 	l.skipWhitespace()
 
+	if l.dialect == DialectPostgreSQL {
+		if tok, ok := l.nextPostgreSQLToken(); ok {
+			return tok
+		}
+	}
+
 	switch l.ch {
 	case '=':
 		if l.peekChar() == '=' {
@@ -41,9 +51,32 @@ func (l *Lexer) NextToken() Token {
 	case '+':
 		tok = newToken(PLUS, l.ch)
 	case '-':
-		tok = newToken(MINUS, l.ch)
+		switch {
+		case l.dialect == DialectMySQL && strings.HasPrefix(l.input[l.position:], LongArrow.String()):
+			l.readChar()
+			l.readChar()
+			tok = Token{Type: LongArrow, Literal: LongArrow.String()}
+		case l.peekChar() == '>':
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: ARROW, Literal: string(ch) + string(l.ch)}
+		default:
+			tok = newToken(MINUS, l.ch)
+		}
 	case '|':
-		tok = newToken(BinaryOr, l.ch)
+		if l.peekChar() == '|' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: CONCAT, Literal: string(ch) + string(l.ch)}
+		} else {
+			tok = newToken(BinaryOr, l.ch)
+		}
+	case '%':
+		tok = newToken(PERCENT, l.ch)
+	case '^':
+		tok = newToken(CARET, l.ch)
+	case '~':
+		tok = newToken(TILDE, l.ch)
 	case '\\':
 		tok = newToken(BinarySlash, l.ch)
 	case '&':
@@ -61,19 +94,18 @@ func (l *Lexer) NextToken() Token {
 	case '*':
 		tok = newToken(ASTERISK, l.ch)
 	case '<':
-		if l.peekChar() == '=' {
-			ch := l.ch
-			l.readChar()
-			tok = Token{Type: LtOrEg, Literal: string(ch) + string(l.ch)}
-		} else {
-			tok = newToken(LT, l.ch)
-		}
+		tok = l.readLessOperator()
 	case '>':
-		if l.peekChar() == '=' {
+		switch l.peekChar() {
+		case '=':
 			ch := l.ch
 			l.readChar()
 			tok = Token{Type: GtOrEg, Literal: string(ch) + string(l.ch)}
-		} else {
+		case '>':
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: ShiftRight, Literal: string(ch) + string(l.ch)}
+		default:
 			tok = newToken(GT, l.ch)
 		}
 	case ';':
@@ -88,15 +120,45 @@ func (l *Lexer) NextToken() Token {
 		tok = newToken(LBRACE, l.ch)
 	case '}':
 		tok = newToken(RBRACE, l.ch)
-	case '"', '\'', '`':
+	case '"', '\'':
 		tok.Type = STRING
 		tok.Literal = l.readString()
+	case '`':
+		tok.Type = QIDENT
+		tok.Literal = l.readString()
 	case '[':
 		tok = newToken(LBRACKET, l.ch)
 	case ']':
 		tok = newToken(RBRACKET, l.ch)
 	case ':':
+		if l.peekChar() == ':' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: DOUBLECOLON, Literal: string(ch) + string(l.ch)}
+
+			break
+		}
+
+		if isLetter(l.peekChar()) {
+			tok.Type = PLACEHOLDER
+			tok.Literal = l.readPlaceholder()
+
+			return tok
+		}
+
 		tok = newToken(COLON, l.ch)
+	case '?':
+		tok = newToken(PLACEHOLDER, l.ch)
+	case '$':
+		if !isDigit(l.peekChar()) {
+			tok = newToken(ILLEGAL, l.ch)
+			break
+		}
+
+		tok.Type = PLACEHOLDER
+		tok.Literal = l.readPlaceholder()
+
+		return tok
 	case '.':
 		tok = newToken(DOT, l.ch)
 
@@ -110,9 +172,24 @@ func (l *Lexer) NextToken() Token {
 
 			return tok
 		} else if isDigit(l.ch) {
+			// the number after dot is tuple element like: t.1.2
+			afterDot := l.position > 0 && l.input[l.position-1] == '.'
 			tok.Type = INT
 			tok.Literal = l.readNumber()
 
+			if l.ch == '.' && isDigit(l.peekChar()) && !afterDot {
+				l.readChar()
+				tok.Type = FLOAT
+				tok.Literal += "." + l.readNumber()
+			}
+
+			if !afterDot {
+				if exp := l.readExponent(); exp != "" {
+					tok.Type = FLOAT
+					tok.Literal += exp
+				}
+			}
+
 			return tok
 		} else {
 			tok = newToken(ILLEGAL, l.ch)
@@ -124,6 +201,98 @@ func (l *Lexer) NextToken() Token {
 	return tok
 }
 
+// readLessOperator reads operators which start with '<' like: <, <=, <>, <=>, <<.
+// The not equal operator <> is the same token as !=.
+func (l *Lexer) readLessOperator() Token {
+	start := l.position
+
+	switch l.peekChar() {
+	case '=':
+		l.readChar()
+
+		if l.peekChar() != '>' {
+			return Token{Type: LtOrEg, Literal: l.input[start : l.position+1]}
+		}
+		l.readChar()
+
+		return Token{Type: NullSafeEq, Literal: l.input[start : l.position+1]}
+	case '>':
+		l.readChar()
+
+		return Token{Type: NotEq, Literal: l.input[start : l.position+1]}
+	case '<':
+		l.readChar()
+
+		return Token{Type: ShiftLeft, Literal: l.input[start : l.position+1]}
+	}
+
+	return newToken(LT, l.ch)
+}
+
+// postgresOperators operators of PostgreSQL, the longest go first.
+var postgresOperators = []TokenType{
+	LongArrow, HashLongArrow, HashArrow, JSONContains, JSONContained, JSONExistsAny, JSONExistsAll, JSONExists,
+}
+
+// nextPostgreSQLToken reads the token which has another meaning in other dialects.
+func (l *Lexer) nextPostgreSQLToken() (Token, bool) {
+	switch {
+	case l.ch == 0:
+		return Token{}, false
+	case l.ch == '"':
+		tok := Token{Type: QIDENT, Literal: l.readString()}
+		l.readChar()
+
+		return tok, true
+	case l.ch == '$' && (l.peekChar() == '$' || isLetter(l.peekChar())):
+		return Token{Type: STRING, Literal: l.readDollarString()}, true
+	}
+
+	for _, op := range postgresOperators {
+		if !strings.HasPrefix(l.input[l.position:], op.String()) {
+			continue
+		}
+
+		for range op.String() {
+			l.readChar()
+		}
+
+		return Token{Type: op, Literal: op.String()}, true
+	}
+
+	return Token{}, false
+}
+
+// readDollarString reads PostgreSQL dollar quoted string like: $$it's$$, $fn$body$fn$.
+func (l *Lexer) readDollarString() string {
+	start := l.position
+	l.readChar()
+
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+
+	if l.ch != '$' {
+		return l.input[start:l.position]
+	}
+
+	tag := l.input[start : l.position+1]
+	l.readChar()
+
+	body := l.position
+	end := strings.Index(l.input[body:], tag)
+	if end < 0 {
+		end = len(l.input) - body
+		l.unterminated = true
+	}
+
+	for l.position < body+end+len(tag) && l.ch != 0 {
+		l.readChar()
+	}
+
+	return l.input[body : body+end]
+}
+
 func newToken(tokenType TokenType, ch byte) Token {
 	return Token{Type: tokenType, Literal: string(ch)}
 }
@@ -134,7 +303,12 @@ func (l *Lexer) readString() string {
 	for {
 		l.readChar()
 
-		if l.ch == start || l.ch == 0 {
+		if l.ch == start {
+			break
+		}
+
+		if l.ch == 0 {
+			l.unterminated = l.position >= len(l.input)
 			break
 		}
 	}
@@ -150,6 +324,17 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[position:l.position]
 }
 
+// readPlaceholder reads named or numbered bind parameter like: :name, $1.
+func (l *Lexer) readPlaceholder() string {
+	position := l.position
+	l.readChar()
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+
+	return l.input[position:l.position]
+}
+
 func isLetter(ch byte) bool {
 	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
 }
@@ -170,6 +355,31 @@ func (l *Lexer) readNumber() string {
 	return l.input[position:l.position]
 }
 
+// readExponent reads the exponent of the number like: e10, E-3, empty if the number has no exponent,
+// so 1e10 is one number and not 1 with the alias e10.
+func (l *Lexer) readExponent() string {
+	if l.ch != 'e' && l.ch != 'E' {
+		return ""
+	}
+
+	next := l.readPosition
+	if next < len(l.input) && (l.input[next] == '+' || l.input[next] == '-') {
+		next++
+	}
+
+	if next >= len(l.input) || !isDigit(l.input[next]) {
+		return ""
+	}
+
+	position := l.position
+	for l.position < next {
+		l.readChar()
+	}
+	l.readNumber()
+
+	return l.input[position:l.position]
+}
+
 func isDigit(ch byte) bool {
 	return '0' <= ch && ch <= '9'
 }
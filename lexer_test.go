@@ -307,3 +307,227 @@ set{1, 2, 4} & set{1, 2, 4}
 		}
 	}
 }
+
+func TestNextTokenPlaceholder(t *testing.T) {
+	t.Parallel()
+
+	input := `id = ? AND name = :name AND date > $12 OR x = $`
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{IDENT, "id"},
+		{ASSIGN, "="},
+		{PLACEHOLDER, "?"},
+		{SQLAnd, "AND"},
+		{IDENT, "name"},
+		{ASSIGN, "="},
+		{PLACEHOLDER, ":name"},
+		{SQLAnd, "AND"},
+		{IDENT, "date"},
+		{GT, ">"},
+		{PLACEHOLDER, "$12"},
+		{SQLOr, "OR"},
+		{IDENT, "x"},
+		{ASSIGN, "="},
+		{ILLEGAL, "$"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenClickHouse(t *testing.T) {
+	t.Parallel()
+
+	input := "SAMPLE 0.1 OFFSET 1/2 t.1.2 x -> x-1 `date` 1e10 1.5E-3 2e+ t.1e2"
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{IDENT, "SAMPLE"},
+		{FLOAT, "0.1"},
+		{IDENT, "OFFSET"},
+		{INT, "1"},
+		{SLASH, "/"},
+		{INT, "2"},
+		{IDENT, "t"},
+		{DOT, "."},
+		{INT, "1"},
+		{DOT, "."},
+		{INT, "2"},
+		{IDENT, "x"},
+		{ARROW, "->"},
+		{IDENT, "x"},
+		{MINUS, "-"},
+		{INT, "1"},
+		{QIDENT, "date"},
+		{FLOAT, "1e10"},
+		{FLOAT, "1.5E-3"},
+		{INT, "2"},
+		{IDENT, "e"},
+		{PLUS, "+"},
+		{IDENT, "t"},
+		{DOT, "."},
+		{INT, "1"},
+		{IDENT, "e2"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenPostgreSQL(t *testing.T) {
+	t.Parallel()
+
+	input := `"Col"::text @> <@ ? ?| ?& -> ->> #> #>> $1 $$it's$$ $fn$a $ b$fn$`
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{QIDENT, "Col"},
+		{DOUBLECOLON, "::"},
+		{IDENT, "text"},
+		{JSONContains, "@>"},
+		{JSONContained, "<@"},
+		{JSONExists, "?"},
+		{JSONExistsAny, "?|"},
+		{JSONExistsAll, "?&"},
+		{ARROW, "->"},
+		{LongArrow, "->>"},
+		{HashArrow, "#>"},
+		{HashLongArrow, "#>>"},
+		{PLACEHOLDER, "$1"},
+		{STRING, "it's"},
+		{STRING, "a $ b"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input, WithDialect(DialectPostgreSQL))
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenMySQL(t *testing.T) {
+	t.Parallel()
+
+	input := "`db`.`order` 'a' \"b\" data->'$.a' data->>'$.b'"
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{QIDENT, "db"},
+		{DOT, "."},
+		{QIDENT, "order"},
+		{STRING, "a"},
+		{STRING, "b"},
+		{IDENT, "data"},
+		{ARROW, "->"},
+		{STRING, "$.a"},
+		{IDENT, "data"},
+		{LongArrow, "->>"},
+		{STRING, "$.b"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input, WithDialect(DialectMySQL))
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenOperators(t *testing.T) {
+	t.Parallel()
+
+	input := "a <> b <=> c <= d << e >> f >= g || h | i % j ^ ~k"
+
+	tests := []struct {
+		expectedType    TokenType
+		expectedLiteral string
+	}{
+		{IDENT, "a"},
+		{NotEq, "<>"},
+		{IDENT, "b"},
+		{NullSafeEq, "<=>"},
+		{IDENT, "c"},
+		{LtOrEg, "<="},
+		{IDENT, "d"},
+		{ShiftLeft, "<<"},
+		{IDENT, "e"},
+		{ShiftRight, ">>"},
+		{IDENT, "f"},
+		{GtOrEg, ">="},
+		{IDENT, "g"},
+		{CONCAT, "||"},
+		{IDENT, "h"},
+		{BinaryOr, "|"},
+		{IDENT, "i"},
+		{PERCENT, "%"},
+		{IDENT, "j"},
+		{CARET, "^"},
+		{TILDE, "~"},
+		{IDENT, "k"},
+		{EOF, ""},
+	}
+
+	l := NewLexer(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package sqlcmp
+
+// Option configures Parser and SemiHash.
+type Option func(*config)
+
+type config struct {
+	functionCasts bool
+	dialect       Dialect
+	recovery      bool
+	truncation    bool
+	tokenFallback bool
+
+	maxDepth      int // 0 is unlimited
+	maxTokens     int // 0 is unlimited
+	maxListLength int // 0 is unlimited
+}
+
+// Dialect of SQL, it enables the syntax which has another meaning in other dialects.
+type Dialect int
+
+const (
+	// DialectGeneric the syntax common for ClickHouse, MySQL and others.
+	DialectGeneric Dialect = iota
+	// DialectPostgreSQL enables dollar quoted strings, "quoted" identifiers, JSON operators and ARRAY[...].
+	DialectPostgreSQL
+	// DialectMySQL enables JSON operators -> and ->> and the precedence of operators of MySQL.
+	DialectMySQL
+)
+
+// DefaultMaxDepth the default limit of nesting of expressions and sub queries, see WithMaxDepth.
+const DefaultMaxDepth = 1000
+
+// DefaultMaxTokens the default limit of the number of tokens of the query, see WithMaxTokens.
+const DefaultMaxTokens = 1000000
+
+// DefaultMaxListLength the default limit of the length of lists, see WithMaxListLength.
+const DefaultMaxListLength = 100000
+
+func newConfig(opts []Option) config {
+	cfg := config{maxDepth: DefaultMaxDepth, maxTokens: DefaultMaxTokens, maxListLength: DefaultMaxListLength}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithFunctionCasts parse ClickHouse type conversion functions like toInt64(x) as CastExpression.
+func WithFunctionCasts() Option {
+	return func(c *config) {
+		c.functionCasts = true
+	}
+}
+
+// WithRecovery keeps the statement when a clause can not be parsed: the clause is skipped up to the next clause
+// keyword and is kept as BadExpression, SemiHash hashes the parsed clauses and returns PartialError.
+// SemiHash returns ErrParse without the hash if none of the requested segments was parsed.
+func WithRecovery() Option {
+	return func(c *config) {
+		c.recovery = true
+	}
+}
+
+// WithTruncation makes SemiHash tolerant to queries which were cut off like in query logs: when the query
+// ends inside of a string or brackets or after an operator, the complete prefix of the query is hashed
+// and ErrTruncated is returned with the hash. The query which fails before its end is not truncated.
+func WithTruncation() Option {
+	return func(c *config) {
+		c.truncation = true
+	}
+}
+
+// WithTokenFallback makes SemiHash return TokenFingerprint of the query which can not be parsed with ErrFallback,
+// so every query gets some hash.
+func WithTokenFallback() Option {
+	return func(c *config) {
+		c.tokenFallback = true
+	}
+}
+
+// WithMaxDepth limits nesting of expressions and sub queries, 0 disables the limit.
+// Each operator of the chain like a + b + c nests the expression one level deeper.
+// The parser stops with ErrLimitExceeded when the limit is exceeded.
+func WithMaxDepth(n int) Option {
+	return func(c *config) {
+		c.maxDepth = n
+	}
+}
+
+// WithMaxTokens limits the number of tokens of the query, 0 disables the limit.
+func WithMaxTokens(n int) Option {
+	return func(c *config) {
+		c.maxTokens = n
+	}
+}
+
+// WithMaxListLength limits the length of lists like IN (1, 2, 3), function arguments and rows of VALUES,
+// 0 disables the limit.
+func WithMaxListLength(n int) Option {
+	return func(c *config) {
+		c.maxListLength = n
+	}
+}
+
+// WithDialect sets SQL dialect of the Lexer and Parser.
+func WithDialect(d Dialect) Option {
+	return func(c *config) {
+		c.dialect = d
+	}
+}
@@ -3,48 +3,113 @@ package sqlcmp
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
+// Operation priority from the lowest to the highest, it follows the SQL standard and PostgreSQL.
+// All binary operators are left associative: a - b - c is (a - b) - c.
 const (
-	// operation priority
 	_ int = iota
 	LOWEST
-	Logic
-	EQUALS
-
-	// ==
-	LESSGREATER // > or <
-	SUM         // +
-	PRODUCT     // *
-	PREFIX      // -X or !X
-	CALL        // myFunction(X)
-	INDEX       // array[index]
+	ALIAS    // ClickHouse expr AS name inside an expression
+	Logic    // OR
+	LogicAnd // AND
+	NEGATION // NOT x
+	IS       // IS [NOT] NULL
+	EQUALS   // = == != <> < > <= >=
+	PATTERN  // LIKE, ILIKE, SIMILAR TO, IN, BETWEEN
+	OPERATOR // ||, bitwise and JSON operators
+	BITAND   // & in MySQL, | has OPERATOR
+	SHIFT    // << >> in MySQL
+	SUM      // + -
+	PRODUCT  // * / %
+	EXPONENT // ^
+	PREFIX   // -X or !X
+	CALL     // myFunction(X)
+	INDEX    // array[index], t.a, a::int
 )
 
+// LESSGREATER the priority of < and >, it is the same as EQUALS.
+const LESSGREATER = EQUALS
+
 var (
 	precedences = map[TokenType]int{
-		EQ:          EQUALS,
-		NotEq:       EQUALS,
-		GtOrEg:      EQUALS,
-		ASSIGN:      EQUALS,
-		SQLIn:       EQUALS,
-		LT:          LESSGREATER,
-		GT:          LESSGREATER,
-		PLUS:        SUM,
-		MINUS:       SUM,
-		SLASH:       PRODUCT,
-		ASTERISK:    PRODUCT,
+		SQLAs:  ALIAS,
+		SQLOr:  Logic,
+		SQLAnd: LogicAnd,
+		ARROW:  Logic,
+
+		SQLIs: IS,
+
+		EQ:         EQUALS,
+		NotEq:      EQUALS,
+		ASSIGN:     EQUALS,
+		LT:         EQUALS,
+		GT:         EQUALS,
+		LtOrEg:     EQUALS,
+		GtOrEg:     EQUALS,
+		NullSafeEq: EQUALS,
+
+		SQLIn:      PATTERN,
+		SQLBetween: PATTERN,
+		SQLLike:    PATTERN,
+		SQLILike:   PATTERN,
+		SQLRLike:   PATTERN,
+		SQLRegexp:  PATTERN,
+		SQLSimilar: PATTERN,
+		SQLNot:     PATTERN, // NOT IN, NOT LIKE, NOT BETWEEN
+
+		CONCAT:        OPERATOR,
+		BinaryOr:      OPERATOR,
+		BinaryAnd:     OPERATOR,
+		BinarySlash:   OPERATOR,
+		ShiftLeft:     OPERATOR,
+		ShiftRight:    OPERATOR,
+		LongArrow:     OPERATOR,
+		HashArrow:     OPERATOR,
+		HashLongArrow: OPERATOR,
+		JSONContains:  OPERATOR,
+		JSONContained: OPERATOR,
+		JSONExists:    OPERATOR,
+		JSONExistsAny: OPERATOR,
+		JSONExistsAll: OPERATOR,
+
+		PLUS:     SUM,
+		MINUS:    SUM,
+		SLASH:    PRODUCT,
+		ASTERISK: PRODUCT,
+		PERCENT:  PRODUCT,
+		CARET:    EXPONENT,
+
 		LPAREN:      CALL,
 		LBRACKET:    INDEX,
-		BinaryOr:    SUM,
-		BinaryAnd:   SUM,
-		BinarySlash: SUM,
-
-		SQLOr:  Logic,
-		SQLAnd: Logic,
-		SQLAs:  EQUALS,
+		DOUBLECOLON: INDEX,
+		DOT:         INDEX,
+	}
 
-		// DOT: EQUALS,
+	// dialectPrecedences operation priority which differs from precedences in the dialect.
+	dialectPrecedences = map[Dialect]map[TokenType]int{
+		DialectPostgreSQL: {
+			ARROW: OPERATOR, // JSON operator instead of lambda
+		},
+		DialectMySQL: {
+			ARROW:  OPERATOR, // JSON operator instead of lambda
+			CONCAT: Logic,    // || is OR unless PIPES_AS_CONCAT
+			// IS, LIKE, IN and REGEXP are comparison operators, BETWEEN binds the comparison on the left of it.
+			SQLIs:      EQUALS,
+			SQLIn:      EQUALS,
+			SQLLike:    EQUALS,
+			SQLRLike:   EQUALS,
+			SQLRegexp:  EQUALS,
+			SQLBetween: EQUALS,
+			SQLNot:     EQUALS,
+			// bitwise operators from the lowest: |, &, << and >>, ^ is XOR which binds tighter than *.
+			BinaryOr:   OPERATOR,
+			BinaryAnd:  BITAND,
+			ShiftLeft:  SHIFT,
+			ShiftRight: SHIFT,
+			CARET:      EXPONENT,
+		},
 	}
 
 	showEnteringLeaving = false
@@ -62,10 +127,21 @@ type Parser struct {
 	errors         []string
 	prefixParseFns map[TokenType][]prefixParseFn
 	infixParseFns  map[TokenType]infixParseFn
+	config         config
+	insert         bool // SELECT is the source of INSERT and ends at ON CONFLICT or RETURNING
+	pos            int  // number of read tokens
+	depth          int  // nesting of expressions and sub queries
+	subSelects     int  // nesting of sub queries, the closing bracket ends the sub query
+	limitExceeded  bool // the parser stopped on the limit of the config
+	eofError       bool // the first error is found at the end of the input
 }
 
-func NewParser(l *Lexer) *Parser {
-	p := &Parser{l: l, errors: []string{}}
+func NewParser(l *Lexer, opts ...Option) *Parser {
+	p := &Parser{l: l, errors: []string{}, config: newConfig(opts)}
+	if p.config.dialect != DialectGeneric {
+		l.dialect = p.config.dialect
+	}
+
 	// Read two tokens, so curToken and peekToken are both set
 	p.nextToken()
 	p.nextToken()
@@ -73,8 +149,12 @@ func NewParser(l *Lexer) *Parser {
 	p.prefixParseFns = make(map[TokenType][]prefixParseFn)
 	p.registerPrefix(IDENT, p.parseIdentifier)
 	p.registerPrefix(INT, p.parseIntegerLiteral)
+	p.registerPrefix(FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(PLACEHOLDER, p.parsePlaceholder)
 	p.registerPrefix(BANG, p.parsePrefixExpression)
 	p.registerPrefix(MINUS, p.parsePrefixExpression)
+	p.registerPrefix(TILDE, p.parsePrefixExpression)
+	p.registerPrefix(SQLNot, p.parsePrefixNotExpression)
 	p.registerPrefix(TRUE, p.parseBoolean)
 	p.registerPrefix(FALSE, p.parseBoolean)
 	p.registerPrefix(LPAREN, p.parseSQLSubSelect)
@@ -83,6 +163,7 @@ func NewParser(l *Lexer) *Parser {
 	p.registerPrefix(IF, p.parseIfExpression)
 	p.registerPrefix(FUNCTION, p.parseFunctionLiteral)
 	p.registerPrefix(STRING, p.parseStringLiteral)
+	p.registerPrefix(QIDENT, p.parseQualifiedName)
 	p.registerPrefix(LBRACKET, p.parseArrayLiteral)
 	p.registerPrefix(SQLSelect, p.parseSQLSubSelect)
 
@@ -101,29 +182,121 @@ func NewParser(l *Lexer) *Parser {
 	p.registerInfix(ASSIGN, p.parseInfixExpression)
 	p.registerInfix(LT, p.parseInfixExpression)
 	p.registerInfix(GT, p.parseInfixExpression)
+	p.registerInfix(NullSafeEq, p.parseInfixExpression)
+	p.registerInfix(CONCAT, p.parseInfixExpression)
+	p.registerInfix(PERCENT, p.parseInfixExpression)
+	p.registerInfix(CARET, p.parseInfixExpression)
+	p.registerInfix(ShiftLeft, p.parseInfixExpression)
+	p.registerInfix(ShiftRight, p.parseInfixExpression)
 	p.registerInfix(LPAREN, p.parseCallExpression)
 	p.registerInfix(LBRACKET, p.parseIndexExpression)
 	p.registerInfix(SQLAnd, p.parseInfixExpression)
 	// parseInfixExpression
 	p.registerInfix(SQLOr, p.parseInfixExpression)
-	p.registerInfix(SQLAs, p.parseInfixExpression)
 	p.registerInfix(SQLIn, p.parseInfixInExpression)
 	p.registerInfix(SQLLike, p.parseInfixExpression)
 	p.registerInfix(SQLBetween, p.parseInfixBetweenExpression)
+	p.registerInfix(SQLILike, p.parseInfixExpression)
+	p.registerInfix(SQLRLike, p.parseInfixExpression)
+	p.registerInfix(SQLRegexp, p.parseInfixExpression)
+	p.registerInfix(SQLSimilar, p.parseInfixSimilarExpression)
+	p.registerInfix(SQLIs, p.parseInfixIsExpression)
+	p.registerInfix(SQLNot, p.parseInfixNotExpression)
+	p.registerInfix(DOUBLECOLON, p.parseInfixCastExpression)
 	p.registerInfix(DOT, p.parseInfixDot)
+	p.registerInfix(ARROW, p.parseLambdaExpression)
+	p.registerInfix(SQLAs, p.parseInfixAlias)
+
+	switch p.config.dialect {
+	case DialectPostgreSQL:
+		p.registerPostgreSQL()
+	case DialectMySQL:
+		p.registerInfix(CONCAT, p.parseInfixOrExpression)
+		// JSON operators: data->'$.a' and data->>'$.a'
+		p.registerInfix(ARROW, p.parseInfixExpression)
+		p.registerInfix(LongArrow, p.parseInfixExpression)
+	}
 
 	return p
 }
 
+// registerPostgreSQL registers parse functions of PostgreSQL operators.
+func (p *Parser) registerPostgreSQL() {
+	p.registerPrefix(JSONExists, p.parsePlaceholder)
+
+	for _, op := range postgresOperators {
+		p.registerInfix(op, p.parseInfixExpression)
+	}
+	p.registerInfix(ARROW, p.parseInfixExpression)
+}
+
 func (p *Parser) nextToken() {
+	p.pos++
 	p.curToken = p.peekToken
+
+	if p.limitExceeded {
+		p.peekToken = Token{Type: EOF}
+		return
+	}
+
 	p.peekToken = p.l.NextToken()
+
+	if p.config.maxTokens > 0 && p.pos > p.config.maxTokens && p.peekToken.Type != EOF {
+		p.exceedLimit(fmt.Sprintf("more than %d tokens", p.config.maxTokens))
+	}
+}
+
+// exceedLimit stops the parser: the rest of the input is skipped, so the parse functions return soon.
+func (p *Parser) exceedLimit(msg string) {
+	if !p.limitExceeded {
+		p.addError("limit exceeded: " + msg)
+	}
+
+	p.limitExceeded = true
+	p.curToken = Token{Type: EOF}
+	p.peekToken = Token{Type: EOF}
+}
+
+// enter checks the limit of nesting before parse of nested expression or sub query, leave has to be called after.
+func (p *Parser) enter() bool {
+	if p.depth++; p.config.maxDepth > 0 && p.depth > p.config.maxDepth {
+		p.exceedLimit(fmt.Sprintf("nesting deeper than %d", p.config.maxDepth))
+		p.depth--
+
+		return false
+	}
+
+	return true
+}
+
+func (p *Parser) leave() {
+	p.depth--
+}
+
+// leaveTo restores the nesting after the chain of infix operators, each operator of the chain nests the left operand
+// one level deeper, so it enters one level too.
+func (p *Parser) leaveTo(depth int) {
+	p.depth = depth
+}
+
+// checkListLength checks the limit of the length of list.
+func (p *Parser) checkListLength(n int) bool {
+	if p.config.maxListLength > 0 && n > p.config.maxListLength {
+		p.exceedLimit(fmt.Sprintf("list longer than %d", p.config.maxListLength))
+
+		return false
+	}
+
+	return true
 }
 
 // ParseProgram зarse зrogram.
-func (p *Parser) ParseProgram() *Program {
-	program := &Program{}
+func (p *Parser) ParseProgram() (program *Program) {
+	program = &Program{}
 	program.Statements = []Statement{}
+
+	defer p.recoverPanic()
+
 	for p.curToken.Type != EOF {
 		if stmt := p.parseStatement(); stmt != nil {
 			program.Statements = append(program.Statements, stmt)
@@ -135,21 +308,46 @@ func (p *Parser) ParseProgram() *Program {
 }
 
 // ParseStatement зarse ыtatement.
-func (p *Parser) ParseStatement() Statement {
+func (p *Parser) ParseStatement() (stmt Statement) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.addError(fmt.Sprintf("%s: %v", ErrInternal, r))
+			stmt = nil
+		}
+	}()
+
 	return p.parseStatement()
 }
 
+// recoverPanic turns panic of the parser into the error of the parser, it has to be deferred.
+func (p *Parser) recoverPanic() {
+	if r := recover(); r != nil {
+		p.addError(fmt.Sprintf("%s: %v", ErrInternal, r))
+	}
+}
+
 func (p *Parser) parseStatement() Statement {
-	switch p.curToken.Type {
-	case LET:
-		return p.parseLetStatement()
-	case RETURN:
-		return p.parseReturnStatement()
-	case SQLSelect:
-		return p.parseSQLSelectStatement()
+	var stmt Statement
+
+	// the parse functions return typed nil, it has to be nil Statement.
+	switch {
+	case p.curTokenIs(LET):
+		if s := p.parseLetStatement(); s != nil {
+			stmt = s
+		}
+	case p.curTokenIs(RETURN):
+		if s := p.parseReturnStatement(); s != nil {
+			stmt = s
+		}
+	case p.curTokenIs(SQLSelect) || p.curInsertStart():
+		stmt = p.parseSQLStatement()
 	default:
-		return p.parseExpressionStatement()
+		if s := p.parseExpressionStatement(); s != nil {
+			stmt = s
+		}
 	}
+
+	return stmt
 }
 
 func (p *Parser) parseLetStatement() *LetStatement {
@@ -189,6 +387,14 @@ func (p *Parser) peekTokenIs(t ...TokenType) bool {
 	return false
 }
 
+func (p *Parser) curSoftKeywordIs(t TokenType) bool {
+	return p.curTokenIs(IDENT) && LookupSoftKeyword(p.curToken.Literal) == t
+}
+
+func (p *Parser) peekSoftKeywordIs(t TokenType) bool {
+	return p.peekTokenIs(IDENT) && LookupSoftKeyword(p.peekToken.Literal) == t
+}
+
 func (p *Parser) expectPeek(t TokenType) bool {
 	if p.peekTokenIs(t) {
 		p.nextToken()
@@ -214,163 +420,196 @@ func (p *Parser) parseReturnStatement() *ReturnStatement {
 
 //nolint:funlen,gocyclo,gocritic
 func (p *Parser) parseSQLSelectStatement() *SQLSelectStatement {
+	if !p.enter() {
+		return nil
+	}
+	defer p.leave()
+
 	stmt := &SQLSelectStatement{Token: p.curToken}
 	p.nextToken()
 
-	// parse columns
-	for !p.curTokenIs(SEMICOLON, EOF, SQLFrom, RPAREN) {
-		if p.curTokenIs(COMMA) {
-			p.nextToken() // next arg
-		}
-
-		if v := p.parseSQLColumn(); v != nil {
-			stmt.SQLSelectColumns = append(stmt.SQLSelectColumns, v)
-		}
-
-		p.nextToken()
+	if !p.parseClause(stmt, SQLSelect, func() bool { return p.parseSQLSelectColumns(stmt) }) {
+		return nil
 	}
 
 	// parse from
 	if !p.curTokenIs(SQLFrom) {
-		if !p.curTokenIs(SEMICOLON, EOF, RPAREN) {
-			p.peekError(SEMICOLON)
-		}
-
-		return stmt
+		return p.parseSQLSelectEnd(stmt)
 	}
 
-	// skip from token
-	p.nextToken()
-
-	for !p.curTokenIs(SEMICOLON, EOF, SQLWhere, SQLGroup, SQLOrder, SQLLimit, SQLInner, SQLLeft, SQLRight, SQLCross, SQLJoin, RPAREN) {
-		if p.curTokenIs(COMMA) {
-			p.nextToken() // next table
-		}
-		if v := p.parseSQLFrom(); v != nil {
-			stmt.From = append(stmt.From, v)
-		}
-		p.nextToken()
+	if !p.parseClause(stmt, SQLFrom, func() bool { return p.parseSQLFromClause(stmt) }) {
+		return nil
 	}
 
-	if p.curTokenIs(RPAREN) {
+	if p.curSubSelectEnd() {
 		return stmt
 	}
-	/*
-		stmt.From = append(stmt.From, p.parseExpression(LOWEST))
-		for p.peekTokenIs(COMMA) {
-			p.nextToken()
-			p.nextToken()
-			stmt.From = append(stmt.From, p.parseExpression(LOWEST))
-		}*/
 
 	// parse join
-	for p.curTokenIs(SQLInner, SQLLeft, SQLRight, SQLCross, SQLJoin) {
-		exp := &SQLJoinExp{Token: Token{Type: SQLJoin}}
-		for !p.curTokenIs(SQLJoin) { // get type
-			exp.Type = p.curToken.Type
-			p.nextToken()
-		}
+	for p.curArrayJoinStart() || p.curJoinStart() || p.curTokenIs(COMMA) {
+		if p.curArrayJoinStart() {
+			ok := p.parseClause(stmt, SQLArray, func() bool {
+				exp := p.parseSQLArrayJoin()
+				if exp == nil {
+					return false
+				}
 
-		if p.curTokenIs(SQLOuter) { // skip outer
-			p.nextToken()
-		}
+				stmt.ArrayJoin = append(stmt.ArrayJoin, exp)
 
-		if !p.curTokenIs(SQLJoin) {
-			p.peekError(SQLJoin)
+				return true
+			})
+			if !ok {
+				return nil
+			}
 
-			return nil
+			continue
 		}
-		// skip join token
-		p.nextToken()
 
-		// get source
-		if v := p.parseSQLSource(); v != nil {
-			exp.Table = v
-		} else {
-			p.peekError(SQLFrom) // TODO: special case error
-			return nil
-		}
+		// comma separated tables are part of FROM even after a join.
+		if p.curTokenIs(COMMA) {
+			if !p.parseClause(stmt, SQLFrom, func() bool { return p.parseSQLFromClause(stmt) }) {
+				return nil
+			}
 
-		if p.curTokenIs(SQLOn) { // parse cond
-			p.nextToken()
+			continue
+		}
 
-			for !p.curTokenIs(SEMICOLON, EOF, SQLOrder, SQLGroup, SQLLimit, SQLWhere, RPAREN, SQLInner, SQLLeft, SQLRight, SQLCross, SQLJoin) {
-				if cond := p.parseSQLCondition(); cond != nil {
-					exp.Cond = append(exp.Cond, cond)
-				}
-				p.nextToken()
+		ok := p.parseClause(stmt, SQLJoin, func() bool {
+			exp := p.parseSQLJoin()
+			if exp == nil {
+				return false
 			}
+
+			exp.After = len(stmt.From)
+			stmt.Join = append(stmt.Join, exp)
+
+			return true
+		})
+		if !ok {
+			return nil
 		}
+	}
 
-		stmt.Join = append(stmt.Join, exp)
+	if p.curTokenIs(SQLPrewhere) {
+		ok := p.parseClause(stmt, SQLPrewhere, func() bool {
+			stmt.Prewhere = p.parseSQLConditions()
+			return true
+		})
+		if !ok {
+			return nil
+		}
 	}
 
 	// parse where
 	if p.curTokenIs(SQLWhere) {
-		p.nextToken()
+		ok := p.parseClause(stmt, SQLWhere, func() bool {
+			stmt.Cond = p.parseSQLConditions()
+			return true
+		})
+		if !ok {
+			return nil
+		}
+
+		if p.curSubSelectEnd() {
+			return stmt
+		}
+	}
 
-		for !p.curTokenIs(SEMICOLON, EOF, SQLOrder, SQLGroup, SQLLimit, RPAREN) {
-			if cond := p.parseSQLCondition(); cond != nil {
-				stmt.Cond = append(stmt.Cond, cond)
+	if p.curTokenIs(SQLGroup) {
+		ok := p.parseClause(stmt, SQLGroup, func() bool {
+			if !p.peekTokenIs(SQLBy) {
+				p.peekError(SQLBy)
+				return false
 			}
+
 			p.nextToken()
+			p.nextToken()
+
+			return p.parseSQLGroupBy(stmt)
+		})
+		if !ok {
+			return nil
 		}
+	}
 
-		if p.curTokenIs(RPAREN) {
-			return stmt
+	if p.curWindowStart() {
+		if !p.parseClause(stmt, SQLWindow, func() bool { return p.parseSQLWindowClause(stmt) }) {
+			return nil
 		}
 	}
 
-	if p.curTokenIs(SQLGroup) {
-		if !p.peekTokenIs(SQLBy) {
-			p.peekError(SQLBy)
+	if p.curTokenIs(SQLOrder) {
+		if !p.parseClause(stmt, SQLOrder, func() bool { return p.parseSQLOrderBy(stmt) }) {
 			return nil
 		}
+	}
 
-		p.nextToken()
-		p.nextToken()
+	if p.curLimitStart() {
+		if !p.parseClause(stmt, SQLLimit, func() bool { return p.parseSQLLimit(stmt) }) {
+			return nil
+		}
+	}
 
-		for !p.curTokenIs(SEMICOLON, EOF, SQLOrder, SQLLimit) {
-			if p.curTokenIs(COMMA) {
-				p.nextToken() // next arg
-			}
-			if v := p.parseSQLSource(); v != nil {
-				stmt.Group = append(stmt.Group, v)
-			}
+	if p.curLockStart() {
+		ok := p.parseClause(stmt, SQLFor, func() bool {
+			stmt.Lock = p.parseSQLLock()
+			return stmt.Lock != nil
+		})
+		if !ok {
+			return nil
 		}
 	}
 
-	if p.curTokenIs(SQLOrder) {
-		if !p.peekTokenIs(SQLBy) {
-			p.peekError(SQLBy)
+	if p.curSoftKeywordIs(SQLSettings) {
+		ok := p.parseClause(stmt, SQLSettings, func() bool {
+			p.nextToken()
+
+			stmt.Settings = p.parseSQLList()
+
+			return stmt.Settings != nil
+		})
+		if !ok {
 			return nil
 		}
-		p.nextToken()
-		p.nextToken()
+	}
 
-		for !p.curTokenIs(SEMICOLON, EOF, SQLLimit) {
-			if p.curTokenIs(COMMA) {
-				p.nextToken() // next arg
-			}
-			if v := p.parseSQLOrder(); v != nil {
-				stmt.Order = append(stmt.Order, v)
+	if p.curSoftKeywordIs(SQLFormat) {
+		ok := p.parseClause(stmt, SQLFormat, func() bool {
+			if !p.expectPeek(IDENT) {
+				return false
 			}
+
+			stmt.Format = p.curToken.Literal
+			p.nextToken()
+
+			return true
+		})
+		if !ok {
+			return nil
 		}
 	}
 
-	if p.curTokenIs(SQLLimit) {
-		p.nextToken()
+	return p.parseSQLSelectEnd(stmt)
+}
 
-		limit := p.parseIntegerLiteral()
-		p.nextToken()
+// parseSQLSelectEnd checks the end of the query. In the recovery mode the rest of the query is kept
+// as bad clauses, so the clauses which are never reached are reported too.
+func (p *Parser) parseSQLSelectEnd(stmt *SQLSelectStatement) *SQLSelectStatement {
+	if p.curSubSelectEnd() || p.curInsertTail() {
+		return stmt
+	}
 
-		if p.curTokenIs(COMMA) {
-			p.nextToken()
-			stmt.Offset = limit
-			stmt.Limit = p.parseIntegerLiteral()
-			p.nextToken()
-		} else {
-			stmt.Limit = limit
+	for !p.curTokenIs(SEMICOLON, EOF) {
+		ok := p.parseClause(stmt, p.curClause(), func() bool {
+			p.addError(fmt.Sprintf("unexpected %s at the end of query", p.curToken.Literal))
+			return false
+		})
+		if !ok {
+			return nil
+		}
+
+		if p.curSubSelectEnd() {
+			return stmt
 		}
 	}
 
@@ -383,96 +622,793 @@ func (p *Parser) parseSQLSelectStatement() *SQLSelectStatement {
 	return stmt
 }
 
-func (p *Parser) parseSQLCondition() Expression {
-	prefixes := p.prefixParseFns[p.curToken.Type]
-	if len(prefixes) == 0 {
-		p.noPrefixParseFnError(p.curToken)
+// parseSQLSelectColumns parse DISTINCT, modifiers and the list of columns, the current token is the token after the list.
+func (p *Parser) parseSQLSelectColumns(stmt *SQLSelectStatement) bool {
+	if p.curSoftKeywordIs(SQLDistinct) && !p.peekTokenIs(COMMA, SQLFrom, SEMICOLON, EOF) {
+		stmt.Distinct = true
+		p.nextToken()
 
-		return nil
+		if p.curTokenIs(SQLOn) {
+			if !p.expectPeek(LPAREN) {
+				return false
+			}
+
+			if stmt.DistinctOn = p.parseExpressionList(RPAREN); len(stmt.DistinctOn) == 0 {
+				p.addError("expected expression in DISTINCT ON")
+				return false
+			}
+			p.nextToken()
+		}
 	}
 
-	for _, prefix := range prefixes {
-		leftExp := prefix()
+	for p.curTokenIs(IDENT) && selectModifiers[strings.ToLower(p.curToken.Literal)] != "" &&
+		!p.peekTokenIs(COMMA, SQLFrom, SEMICOLON, EOF) {
+		stmt.Modifiers = append(stmt.Modifiers, selectModifiers[strings.ToLower(p.curToken.Literal)])
+		p.nextToken()
+	}
 
-		if leftExp == nil {
-			continue
+	for !p.curTokenIs(SEMICOLON, EOF, SQLFrom, RPAREN) && !p.curInsertTail() {
+		if p.curTokenIs(COMMA) {
+			p.nextToken() // next arg
 		}
 
-		// try parse infix
-		for !p.peekTokenIs(SEMICOLON, EOF) && LOWEST <= p.peekPrecedence() {
-			infix := p.infixParseFns[p.peekToken.Type]
-			if infix == nil {
-				return &SQLCondition{Expression: leftExp}
-			}
-
-			p.nextToken()
-			leftExp = infix(leftExp)
+		// the parser stays at the wrong token, so the next clause is not skipped in the recovery mode
+		v := p.parseSQLColumn()
+		if v == nil {
+			return false
 		}
+		stmt.SQLSelectColumns = append(stmt.SQLSelectColumns, v)
 
-		return &SQLCondition{Expression: leftExp}
+		p.nextToken()
+
+		if !p.curTokenIs(COMMA, SEMICOLON, EOF, SQLFrom, RPAREN) && !p.curInsertTail() {
+			p.addError(fmt.Sprintf("expected comma, got %s instead", p.curToken.Literal))
+
+			return false
+		}
 	}
 
-	return nil
+	return true
 }
 
-func (p *Parser) Errors() []string {
-	return p.errors
-}
+// parseSQLFromClause parse tables of FROM clause, the current token is FROM or the comma after a join.
+func (p *Parser) parseSQLFromClause(stmt *SQLSelectStatement) bool {
+	// skip from token
+	p.nextToken()
 
-func (p *Parser) addError(msg string) {
-	p.errors = append(p.errors, msg)
-}
+	for !p.curClauseStart() && !p.curJoinStart() && !p.curArrayJoinStart() {
+		if p.curTokenIs(COMMA) {
+			p.nextToken() // next table
+		}
+		v := p.parseSQLTable()
+		if v == nil {
+			return false
+		}
+		stmt.From = append(stmt.From, v)
+		p.nextToken()
 
-func (p *Parser) peekError(t TokenType) {
-	msg := fmt.Sprintf("expected next token to be %s, got %s instead",
-		t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
-}
+		if !p.parseSQLTableModifiers(stmt) {
+			return false
+		}
 
-func (p *Parser) registerPrefix(tokenType TokenType, fn prefixParseFn) {
-	p.prefixParseFns[tokenType] = append(p.prefixParseFns[tokenType], fn)
-}
+		if !p.curTokenIs(COMMA) && !p.curClauseStart() && !p.curJoinStart() && !p.curArrayJoinStart() {
+			p.addError(fmt.Sprintf("expected comma, got %s instead", p.curToken.Literal))
 
-func (p *Parser) registerInfix(tokenType TokenType, fn infixParseFn) {
-	p.infixParseFns[tokenType] = fn
+			return false
+		}
+	}
+
+	return true
 }
 
-func (p *Parser) parseExpressionStatement() *ExpressionStatement {
-	defer untrace(trace("parseExpressionStatement"))
+// parseSQLOrderBy parse list of ORDER BY clause, the current token is ORDER.
+func (p *Parser) parseSQLOrderBy(stmt *SQLSelectStatement) bool {
+	if !p.peekTokenIs(SQLBy) {
+		p.peekError(SQLBy)
+		return false
+	}
+	p.nextToken()
+	p.nextToken()
 
-	stmt := &ExpressionStatement{Token: p.curToken}
-	stmt.Expression = p.parseExpression(LOWEST)
-	if p.peekTokenIs(SEMICOLON) {
+	for {
+		v := p.parseSQLOrder()
+		if v == nil {
+			return false
+		}
+		stmt.Order = append(stmt.Order, v)
+
+		if !p.peekTokenIs(COMMA) {
+			break
+		}
 		p.nextToken()
+		p.nextToken() // next arg
 	}
-	return stmt
-}
+	p.nextToken()
 
-func (p *Parser) parseExpression(precedence int) Expression {
-	defer untrace(trace("parseExpression"))
+	return true
+}
 
-	prefixs := p.prefixParseFns[p.curToken.Type]
-	if len(prefixs) == 0 {
-		p.noPrefixParseFnError(p.curToken)
+// parseClause runs parse of one clause of the query. In the recovery mode the clause which failed or reported
+// errors is kept as BadExpression of the statement and the rest of the clause is skipped up to the next clause,
+// so the other clauses still are parsed. False if the statement can not be parsed.
+func (p *Parser) parseClause(stmt *SQLSelectStatement, clause TokenType, parse func() bool) bool {
+	pos, errs := p.pos, len(p.errors)
 
-		return nil
+	ok := parse() && len(p.errors) == errs
+	if ok || !p.config.recovery {
+		return ok
 	}
 
-	for _, prefix := range prefixs {
-		leftExp := prefix()
+	bad := &BadExpression{Token: p.curToken, Clause: clause, Errors: p.errors[errs:len(p.errors):len(p.errors)]}
+	bad.Tokens = p.skipClause(pos)
+	stmt.Bad = append(stmt.Bad, bad)
 
-		if leftExp == nil {
-			continue
-		}
+	return true
+}
 
-		for !p.peekTokenIs(SEMICOLON) && precedence < p.peekPrecedence() {
-			infix := p.infixParseFns[p.peekToken.Type]
-			if infix == nil {
-				return leftExp
-			}
+// skipClause skips tokens up to the next clause of the query and returns them, at least one token is skipped
+// if the parser did not move since pos.
+func (p *Parser) skipClause(pos int) []Token {
+	var (
+		tokens []Token
+		depth  int
+	)
 
-			p.nextToken()
-			leftExp = infix(leftExp)
+	for !p.curTokenIs(SEMICOLON, EOF) {
+		if p.pos != pos && depth <= 0 && p.curSyncStart() {
+			break
+		}
+
+		switch {
+		case p.curTokenIs(LPAREN):
+			depth++
+		case p.curTokenIs(RPAREN):
+			depth--
+		}
+
+		tokens = append(tokens, p.curToken)
+		p.nextToken()
+	}
+
+	return tokens
+}
+
+// curSyncStart checks that the current token starts a clause of the query, the parser continues from it after error.
+func (p *Parser) curSyncStart() bool {
+	return p.curTokenIs(SQLFrom) || p.curClauseStart() || p.curJoinStart() || p.curArrayJoinStart()
+}
+
+// curClauseStart checks that the current token ends the FROM clause or the conditions of the query.
+func (p *Parser) curClauseStart() bool {
+	if p.curTokenIs(SEMICOLON, EOF, RPAREN, SQLPrewhere, SQLWhere, SQLGroup, SQLOrder) {
+		return true
+	}
+
+	return p.curWindowStart() || p.curLimitStart() || p.curSoftKeywordIs(SQLSettings) || p.curSoftKeywordIs(SQLFormat) ||
+		p.curInsertTail() || p.curLockStart()
+}
+
+// curClause returns the keyword of the clause which starts at the current token like: JOIN for LEFT JOIN,
+// ILLEGAL if the token does not start a clause.
+func (p *Parser) curClause() TokenType {
+	switch {
+	case p.curTokenIs(SQLFrom, SQLPrewhere, SQLWhere, SQLGroup, SQLOrder):
+		return p.curToken.Type
+	case p.curWindowStart():
+		return SQLWindow
+	case p.curArrayJoinStart():
+		return SQLArray
+	case p.curJoinStart():
+		return SQLJoin
+	case p.curLimitStart():
+		return SQLLimit
+	case p.curLockStart():
+		return SQLFor
+	case p.curSoftKeywordIs(SQLSettings), p.curSoftKeywordIs(SQLFormat):
+		return LookupSoftKeyword(p.curToken.Literal)
+	}
+
+	return ILLEGAL
+}
+
+// curSubSelectEnd checks that the current token closes the sub query, the bracket is unexpected in the top query.
+func (p *Parser) curSubSelectEnd() bool {
+	return p.curTokenIs(RPAREN) && p.subSelects > 0
+}
+
+// curWindowStart checks that the current token starts the WINDOW clause: WINDOW is followed by the name of window.
+func (p *Parser) curWindowStart() bool {
+	return p.curSoftKeywordIs(SQLWindow) && p.peekTokenIs(IDENT)
+}
+
+// curLimitStart checks that the current token starts the pagination clause: LIMIT, OFFSET or FETCH.
+func (p *Parser) curLimitStart() bool {
+	return p.curTokenIs(SQLLimit) || p.curSoftKeywordIs(SQLOffset) || p.curSoftKeywordIs(SQLFetch)
+}
+
+// curLockStart checks that the current token starts the locking clause: FOR UPDATE, FOR SHARE or LOCK IN SHARE MODE.
+func (p *Parser) curLockStart() bool {
+	if p.curSoftKeywordIs(SQLFor) {
+		return p.peekSoftKeywordIs(SQLUpdate) || p.peekSoftKeywordIs(SQLShare)
+	}
+
+	return p.curSoftKeywordIs(SQLLock) && p.peekTokenIs(SQLIn)
+}
+
+// parseSQLLock parse FOR {UPDATE | SHARE} [OF t, ...] [NOWAIT | SKIP LOCKED] or LOCK IN SHARE MODE,
+// the current token is the token after the clause.
+func (p *Parser) parseSQLLock() *LockExp {
+	exp := &LockExp{Token: p.curToken}
+
+	if p.curSoftKeywordIs(SQLLock) {
+		p.nextToken() // skip lock
+
+		for _, t := range []TokenType{SQLShare, SQLMode} {
+			if !p.peekSoftKeywordIs(t) {
+				p.peekError(t)
+				return nil
+			}
+			p.nextToken()
+		}
+		p.nextToken()
+
+		exp.ShareMode = true
+		exp.Strength = SQLShare
+
+		return exp
+	}
+
+	p.nextToken()
+	exp.Strength = LookupSoftKeyword(p.curToken.Literal)
+	p.nextToken()
+
+	if p.curSoftKeywordIs(SQLOf) {
+		for {
+			if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+				p.peekError(IDENT)
+				return nil
+			}
+			p.nextToken()
+
+			exp.Of = append(exp.Of, p.curToken.Literal)
+			p.nextToken()
+
+			if !p.curTokenIs(COMMA) {
+				break
+			}
+		}
+	}
+
+	switch {
+	case p.curSoftKeywordIs(SQLNowait):
+		exp.Wait = SQLNowait.String()
+		p.nextToken()
+	case p.curSoftKeywordIs(SQLSkip):
+		if !p.peekSoftKeywordIs(SQLLocked) {
+			p.peekError(SQLLocked)
+			return nil
+		}
+		p.nextToken()
+		p.nextToken()
+
+		exp.Wait = SQLSkip.String() + " " + SQLLocked.String()
+	}
+
+	return exp
+}
+
+// parseSQLConditions parse conditions of WHERE or PREWHERE clause, the current token is the clause keyword.
+func (p *Parser) parseSQLConditions() []Expression {
+	var list []Expression
+
+	p.nextToken()
+
+	for !p.curClauseStart() {
+		errs := len(p.errors)
+
+		// the parser stays at the wrong token, so the next clause is not skipped in the recovery mode
+		cond := p.parseSQLCondition()
+		if cond == nil || len(p.errors) != errs {
+			return list
+		}
+		list = append(list, cond)
+		p.nextToken()
+	}
+
+	return list
+}
+
+// parseSQLList parse comma separated list of expressions, the current token is the token after the list.
+func (p *Parser) parseSQLList() []Expression {
+	var list []Expression
+
+	for {
+		exp := p.parseExpression(LOWEST)
+		if exp == nil {
+			return nil
+		}
+		list = append(list, exp)
+
+		if !p.peekTokenIs(COMMA) || !p.checkListLength(len(list)+1) {
+			break
+		}
+		p.nextToken()
+		p.nextToken()
+	}
+	p.nextToken()
+
+	return list
+}
+
+// parseSQLTableModifiers parse ClickHouse FINAL and SAMPLE k [OFFSET m] after the table.
+func (p *Parser) parseSQLTableModifiers(stmt *SQLSelectStatement) bool {
+	if p.curSoftKeywordIs(SQLFinal) {
+		stmt.Final = true
+		p.nextToken()
+	}
+
+	if !p.curSoftKeywordIs(SQLSample) {
+		return true
+	}
+	p.nextToken()
+
+	if stmt.Sample = p.parseExpression(LOWEST); stmt.Sample == nil {
+		return false
+	}
+	p.nextToken()
+
+	if p.curSoftKeywordIs(SQLOffset) {
+		p.nextToken()
+
+		if stmt.SampleOffset = p.parseExpression(LOWEST); stmt.SampleOffset == nil {
+			return false
+		}
+		p.nextToken()
+	}
+
+	return true
+}
+
+// curArrayJoinStart checks that the current token starts ClickHouse [LEFT] ARRAY JOIN.
+func (p *Parser) curArrayJoinStart() bool {
+	if p.curTokenIs(SQLLeft) {
+		return p.peekSoftKeywordIs(SQLArray)
+	}
+
+	return p.curSoftKeywordIs(SQLArray) && p.peekTokenIs(SQLJoin)
+}
+
+// parseSQLArrayJoin parse ClickHouse [LEFT] ARRAY JOIN arr AS a, arr2 AS b.
+func (p *Parser) parseSQLArrayJoin() *ArrayJoinExp {
+	exp := &ArrayJoinExp{Token: p.curToken}
+
+	if p.curTokenIs(SQLLeft) {
+		exp.Left = true
+		p.nextToken()
+	}
+
+	if !p.expectPeek(SQLJoin) {
+		return nil
+	}
+	p.nextToken()
+
+	for {
+		v := p.parseSQLColumn()
+		if v == nil {
+			return nil
+		}
+		exp.Items = append(exp.Items, v)
+
+		if !p.peekTokenIs(COMMA) {
+			break
+		}
+		p.nextToken()
+		p.nextToken()
+	}
+	p.nextToken()
+
+	return exp
+}
+
+// curJoinStart checks that the current token starts a join operator like: LEFT JOIN, GLOBAL ANY JOIN.
+func (p *Parser) curJoinStart() bool {
+	if p.curTokenIs(SQLInner, SQLLeft, SQLRight, SQLCross, SQLJoin) {
+		return true
+	}
+
+	return isJoinWord(p.curToken) && isJoinWord(p.peekToken)
+}
+
+// isJoinWord checks that the token can be a part of join operator.
+func isJoinWord(tok Token) bool {
+	switch tok.Type {
+	case SQLInner, SQLLeft, SQLRight, SQLCross, SQLOuter, SQLJoin:
+		return true
+	case IDENT:
+		switch LookupSoftKeyword(tok.Literal) {
+		case SQLFull, SQLNatural, SQLGlobal, SQLAny, SQLAll, SQLAsof, SQLSemi, SQLAnti:
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSQLJoin parse a join like: [GLOBAL] [NATURAL] [ANY|ALL|ASOF|SEMI|ANTI] [INNER|LEFT|RIGHT|FULL|CROSS] [OUTER] JOIN
+// table [ON cond | USING (columns)].
+func (p *Parser) parseSQLJoin() *SQLJoinExp {
+	exp := &SQLJoinExp{Token: Token{Type: SQLJoin}}
+
+	for !p.curTokenIs(SQLJoin) {
+		switch {
+		case p.curTokenIs(SQLInner, SQLLeft, SQLRight, SQLCross):
+			exp.Type = p.curToken.Type
+		case p.curTokenIs(SQLOuter): // skip outer
+		case p.curTokenIs(IDENT) && isJoinWord(p.curToken):
+			switch tok := LookupSoftKeyword(p.curToken.Literal); tok {
+			case SQLFull:
+				exp.Type = tok
+			case SQLNatural:
+				exp.Natural = true
+			case SQLGlobal:
+				exp.Global = true
+			default:
+				exp.Strictness = tok
+			}
+		default:
+			p.addError(fmt.Sprintf("expected %s, got %s instead", SQLJoin, p.curToken.Literal))
+
+			return nil
+		}
+
+		p.nextToken()
+	}
+	// skip join token
+	p.nextToken()
+
+	// get source
+	if v := p.parseSQLTable(); v != nil {
+		exp.Table = v
+	} else {
+		p.addError("expected table after " + SQLJoin.String())
+		return nil
+	}
+	p.nextToken()
+
+	switch {
+	case p.curTokenIs(SQLOn): // parse cond
+		p.nextToken()
+
+		for !p.curClauseStart() && !p.curTokenIs(COMMA) && !p.curJoinStart() && !p.curArrayJoinStart() {
+			cond := p.parseSQLCondition()
+			if cond == nil {
+				return nil
+			}
+			exp.Cond = append(exp.Cond, cond)
+			p.nextToken()
+		}
+
+		if len(exp.Cond) == 0 {
+			p.addError(fmt.Sprintf("expected condition after %s, got %s instead", SQLOn, p.curToken.Literal))
+			return nil
+		}
+	case p.curSoftKeywordIs(SQLUsing):
+		if p.peekTokenIs(LPAREN) {
+			p.nextToken()
+
+			if exp.Using = p.parseSQLColumnAliases(); exp.Using == nil {
+				return nil
+			}
+		} else {
+			if !p.expectPeek(IDENT) {
+				return nil
+			}
+
+			exp.Using = []string{p.curToken.Literal}
+		}
+		p.nextToken()
+	}
+
+	return exp
+}
+
+// parseSQLWindowClause parse named windows like: WINDOW w AS (PARTITION BY a), w2 AS (w ORDER BY b).
+func (p *Parser) parseSQLWindowClause(stmt *SQLSelectStatement) bool {
+	for p.curSoftKeywordIs(SQLWindow) || p.curTokenIs(COMMA) {
+		if !p.expectPeek(IDENT) {
+			return false
+		}
+
+		exp := &SQLWindowExp{Token: p.curToken, Name: p.curToken.Literal}
+
+		if !p.expectPeek(SQLAs) {
+			return false
+		}
+
+		if !p.expectPeek(LPAREN) {
+			return false
+		}
+
+		if exp.Spec = p.parseWindowBody(&WindowSpec{Token: p.curToken}); exp.Spec == nil {
+			return false
+		}
+
+		stmt.Window = append(stmt.Window, exp)
+		p.nextToken()
+	}
+
+	return true
+}
+
+// parseSQLLimit parse LIMIT [offset,] count, LIMIT count|ALL OFFSET offset,
+// OFFSET offset ROWS FETCH FIRST count ROWS ONLY and ClickHouse LIMIT count BY columns.
+// Each of the count, the offset and LIMIT BY is given once, FETCH is not allowed after the count of LIMIT.
+//
+//nolint:funlen,gocyclo
+func (p *Parser) parseSQLLimit(stmt *SQLSelectStatement) bool {
+	var count, offset bool
+
+	// duplicate reports the part of the clause which is already given.
+	duplicate := func(seen bool, part TokenType) bool {
+		if seen {
+			p.addError(fmt.Sprintf("duplicate %s in %s clause", part, SQLLimit))
+		}
+
+		return seen
+	}
+
+	for p.curLimitStart() {
+		switch {
+		case p.curTokenIs(SQLLimit):
+			exp := &LimitByExp{Token: p.curToken}
+			p.nextToken()
+
+			if p.curSoftKeywordIs(SQLAll) {
+				if duplicate(count, SQLLimit) {
+					return false
+				}
+				count = true
+				stmt.LimitAll = true
+				p.nextToken()
+
+				continue
+			}
+
+			if exp.Limit = p.parseExpression(LOWEST); exp.Limit == nil {
+				return false
+			}
+			p.nextToken()
+
+			kind := LimitComma
+			switch {
+			case p.curTokenIs(COMMA):
+				p.nextToken()
+				exp.Offset = exp.Limit
+				if exp.Limit = p.parseExpression(LOWEST); exp.Limit == nil {
+					return false
+				}
+				p.nextToken()
+			case p.curSoftKeywordIs(SQLOffset):
+				p.nextToken()
+				if exp.Offset = p.parseExpression(LOWEST); exp.Offset == nil {
+					return false
+				}
+				p.nextToken()
+				kind = LimitOffset
+
+				if p.curSoftKeywordIs(SQLRows) || p.curSoftKeywordIs(SQLRow) {
+					p.nextToken()
+				}
+			}
+
+			// ClickHouse LIMIT n BY columns
+			if p.curTokenIs(SQLBy) {
+				if duplicate(stmt.LimitBy != nil, SQLBy) {
+					return false
+				}
+				p.nextToken()
+
+				if exp.Columns = p.parseSQLList(); exp.Columns == nil {
+					return false
+				}
+				stmt.LimitBy = exp
+
+				continue
+			}
+
+			if duplicate(count, SQLLimit) || (exp.Offset != nil && duplicate(offset, SQLOffset)) {
+				return false
+			}
+			count, offset = true, offset || exp.Offset != nil
+
+			stmt.Limit = exp.Limit
+			if exp.Offset != nil {
+				stmt.Offset = exp.Offset
+			}
+
+			if kind == LimitOffset && stmt.LimitKind == LimitComma {
+				stmt.LimitKind = LimitOffset
+			}
+		case p.curSoftKeywordIs(SQLOffset):
+			if duplicate(offset, SQLOffset) {
+				return false
+			}
+			offset = true
+			p.nextToken()
+
+			if stmt.Offset = p.parseExpression(LOWEST); stmt.Offset == nil {
+				return false
+			}
+			p.nextToken()
+
+			if p.curSoftKeywordIs(SQLRows) || p.curSoftKeywordIs(SQLRow) {
+				p.nextToken()
+			}
+
+			if stmt.LimitKind == LimitComma {
+				stmt.LimitKind = LimitOffset
+			}
+		default: // FETCH
+			if count {
+				p.addError(fmt.Sprintf("unexpected %s after %s", SQLFetch, SQLLimit))
+				return false
+			}
+			count = true
+			stmt.LimitKind = LimitFetch
+
+			if !p.peekSoftKeywordIs(SQLFirst) && !p.peekSoftKeywordIs(SQLNext) {
+				p.peekError(SQLFirst)
+				return false
+			}
+			p.nextToken()
+			p.nextToken()
+
+			if !p.curSoftKeywordIs(SQLRows) && !p.curSoftKeywordIs(SQLRow) {
+				if stmt.Limit = p.parseExpression(LOWEST); stmt.Limit == nil {
+					return false
+				}
+				p.nextToken()
+			}
+
+			if !p.curSoftKeywordIs(SQLRows) && !p.curSoftKeywordIs(SQLRow) {
+				p.addError(fmt.Sprintf("expected %s, got %s instead", SQLRows, p.curToken.Literal))
+				return false
+			}
+
+			if !p.peekSoftKeywordIs(SQLOnly) {
+				p.peekError(SQLOnly)
+				return false
+			}
+			p.nextToken()
+			p.nextToken()
+		}
+	}
+
+	return true
+}
+
+func (p *Parser) parseSQLCondition() Expression {
+	if !p.enter() {
+		return nil
+	}
+	defer p.leaveTo(p.depth - 1)
+
+	prefixes := p.prefixParseFns[p.curToken.Type]
+	if len(prefixes) == 0 {
+		p.noPrefixParseFnError(p.curToken)
+
+		return nil
+	}
+
+	for _, prefix := range prefixes {
+		leftExp := prefix()
+
+		if leftExp == nil {
+			continue
+		}
+
+		// try parse infix
+		for !p.peekTokenIs(SEMICOLON, EOF) && LOWEST <= p.peekPrecedence() {
+			infix := p.infixParseFns[infixType(p.peekToken)]
+			if infix == nil {
+				return &SQLCondition{Expression: leftExp}
+			}
+
+			if !p.enter() {
+				return nil
+			}
+
+			p.nextInfixToken()
+
+			if leftExp = infix(leftExp); leftExp == nil {
+				return nil
+			}
+		}
+
+		return &SQLCondition{Expression: leftExp}
+	}
+
+	return nil
+}
+
+func (p *Parser) Errors() []string {
+	return p.errors
+}
+
+// addError records the error of the parse, errors after the limit is exceeded are only the unwinding of the parser and are dropped.
+func (p *Parser) addError(msg string) {
+	if p.limitExceeded {
+		return
+	}
+
+	if len(p.errors) == 0 {
+		p.eofError = p.curTokenIs(EOF) || p.peekTokenIs(EOF)
+	}
+	p.errors = append(p.errors, msg)
+}
+
+func (p *Parser) peekError(t TokenType) {
+	msg := fmt.Sprintf("expected next token to be %s, got %s instead",
+		t, p.peekToken.Type)
+	p.addError(msg)
+}
+
+func (p *Parser) registerPrefix(tokenType TokenType, fn prefixParseFn) {
+	p.prefixParseFns[tokenType] = append(p.prefixParseFns[tokenType], fn)
+}
+
+func (p *Parser) registerInfix(tokenType TokenType, fn infixParseFn) {
+	p.infixParseFns[tokenType] = fn
+}
+
+func (p *Parser) parseExpressionStatement() *ExpressionStatement {
+	defer untrace(trace("parseExpressionStatement"))
+
+	stmt := &ExpressionStatement{Token: p.curToken}
+	stmt.Expression = p.parseExpression(LOWEST)
+	if p.peekTokenIs(SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseExpression(precedence int) Expression {
+	defer untrace(trace("parseExpression"))
+
+	if !p.enter() {
+		return nil
+	}
+	defer p.leaveTo(p.depth - 1)
+
+	prefixs := p.prefixParseFns[p.curToken.Type]
+	if len(prefixs) == 0 {
+		p.noPrefixParseFnError(p.curToken)
+
+		return nil
+	}
+
+	for _, prefix := range prefixs {
+		leftExp := prefix()
+
+		if leftExp == nil {
+			continue
+		}
+
+		for !p.peekTokenIs(SEMICOLON) && precedence < p.peekPrecedence() {
+			infix := p.infixParseFns[infixType(p.peekToken)]
+			if infix == nil {
+				return leftExp
+			}
+
+			if !p.enter() {
+				return nil
+			}
+
+			p.nextInfixToken()
+
+			if leftExp = infix(leftExp); leftExp == nil {
+				return nil
+			}
 		}
 
 		return leftExp
@@ -482,33 +1418,88 @@ func (p *Parser) parseExpression(precedence int) Expression {
 }
 
 func (p *Parser) parseIdentifier() Expression {
-	exp := &Identifier{Token: p.curToken, Value: p.curToken.Literal}
-	if p.peekTokenIs(DOT) {
+	if p.peekTokenIs(STRING) {
+		if tp, ok := typedLiterals[strings.ToLower(p.curToken.Literal)]; ok {
+			exp := &TypedLiteral{Token: p.curToken, Type: tp}
+			p.nextToken()
+			exp.Value = p.curToken.Literal
+
+			return exp
+		}
+	}
+
+	if p.curSoftKeywordIs(SQLInterval) && p.peekTokenIs(STRING, INT, PLACEHOLDER) {
+		return p.parseIntervalLiteral()
+	}
+
+	// EXISTS is a name of column unless the sub query follows.
+	if p.curSoftKeywordIs(SQLExists) && p.peekTokenIs(LPAREN) {
+		return p.parseExistsExpression()
+	}
+
+	if p.curSoftKeywordIs(SQLArray) && p.peekTokenIs(LBRACKET) && p.config.dialect == DialectPostgreSQL {
 		p.nextToken()
 
-		// @todo: maybe DOT like infix
-		exp.Value += DOT.String()
+		return p.parseArrayLiteral()
+	}
+
+	if p.peekTokenIs(LPAREN) {
+		// the name of function, names of tables and columns are QualifiedName
+		return &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	return p.parseQualifiedName()
+}
+
+// parseQualifiedName parse dotted name like: db.users, t.*, `db`.`users` or tuple element access like: t.1.
+// The current token is the first part of the name.
+func (p *Parser) parseQualifiedName() Expression {
+	name := &QualifiedName{Token: p.curToken}
+	if p.config.dialect == DialectPostgreSQL {
+		name.Quote = `"`
+	}
+	name.add(p.curToken)
+
+	for p.peekTokenIs(DOT) {
+		p.nextToken()
+
+		if p.peekTokenIs(INT) {
+			return p.parseInfixDot(name)
+		}
 
-		if !p.peekTokenIs(IDENT, ASTERISK) {
+		if !p.peekTokenIs(IDENT, STRING, QIDENT, ASTERISK) {
 			p.peekError(IDENT)
 			return nil
 		}
 		p.nextToken()
 
-		exp.Value += p.curToken.Literal
+		name.add(p.curToken)
 	}
 
-	return exp
+	return name
 }
 
 func (p *Parser) parseIntegerLiteral() Expression {
 	defer untrace(trace("parseIntegerLiteral"))
 
-	lit := &IntegerLiteral{Token: p.curToken}
-	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
+	lit := &IntegerLiteral{Token: p.curToken}
+	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
+	if err != nil {
+		msg := fmt.Sprintf("could not parse %q as integer", p.curToken.Literal)
+		p.addError(msg)
+		return nil
+	}
+	lit.Value = value
+
+	return lit
+}
+
+func (p *Parser) parseFloatLiteral() Expression {
+	lit := &FloatLiteral{Token: p.curToken}
+	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
+		msg := fmt.Sprintf("could not parse %q as float", p.curToken.Literal)
+		p.addError(msg)
 		return nil
 	}
 	lit.Value = value
@@ -516,12 +1507,16 @@ func (p *Parser) parseIntegerLiteral() Expression {
 	return lit
 }
 
+func (p *Parser) parsePlaceholder() Expression {
+	return &Placeholder{Token: Token{Type: PLACEHOLDER, Literal: p.curToken.Literal}}
+}
+
 func (p *Parser) noPrefixParseFnError(t Token) {
 	msg := fmt.Sprintf(
 		"no prefix parse function for %s found, literal: %s, cur token: %s",
 		t.Type.String(), t.Literal, p.curToken.Literal)
 
-	p.errors = append(p.errors, msg)
+	p.addError(msg)
 }
 
 func (p *Parser) parsePrefixExpression() Expression {
@@ -537,17 +1532,68 @@ func (p *Parser) parsePrefixExpression() Expression {
 	return expression
 }
 
+// parsePrefixNotExpression parse logical negation like: NOT a = 1.
+func (p *Parser) parsePrefixNotExpression() Expression {
+	defer untrace(trace("parsePrefixNotExpression"))
+
+	expression := &PrefixExpression{
+		Token:    p.curToken,
+		Operator: SQLNot.String(),
+	}
+	p.nextToken()
+
+	if p.curSoftKeywordIs(SQLExists) && p.peekTokenIs(LPAREN) {
+		exp, ok := p.parseExistsExpression().(*ExistsExpression)
+		if !ok {
+			return nil
+		}
+		exp.Not = true
+
+		return exp
+	}
+
+	// NOT binds weaker than comparison operators.
+	expression.Right = p.parseExpression(NEGATION)
+
+	return expression
+}
+
 func (p *Parser) peekPrecedence() int {
-	if v, ok := precedences[p.peekToken.Type]; ok {
-		return v
+	return p.precedence(infixType(p.peekToken))
+}
+
+// nextInfixToken moves to the operator after an operand, the soft keyword operator gets its own type.
+func (p *Parser) nextInfixToken() {
+	t := infixType(p.peekToken)
+	p.nextToken()
+	p.curToken.Type = t
+}
+
+// infixType returns the type of the token after an operand: ILIKE, RLIKE, REGEXP and SIMILAR are soft keywords,
+// they are operators only after an operand and names of columns elsewhere.
+func infixType(tok Token) TokenType {
+	if tok.Type == IDENT {
+		switch t := LookupSoftKeyword(tok.Literal); t {
+		case SQLILike, SQLRLike, SQLRegexp, SQLSimilar:
+			return t
+		}
 	}
 
-	return LOWEST
+	return tok.Type
 }
 
 func (p *Parser) curPrecedence() int {
-	if operator, ok := precedences[p.curToken.Type]; ok {
-		return operator
+	return p.precedence(p.curToken.Type)
+}
+
+// precedence returns operation priority of the token in the dialect of the parser.
+func (p *Parser) precedence(t TokenType) int {
+	if v, ok := dialectPrecedences[p.config.dialect][t]; ok {
+		return v
+	}
+
+	if v, ok := precedences[t]; ok {
+		return v
 	}
 
 	return LOWEST
@@ -565,8 +1611,23 @@ func (p *Parser) parseAsterisk() Expression {
 }
 
 func (p *Parser) parseSQLGroupedCondition() Expression {
+	tok := p.curToken
 	p.nextToken() // skip LPAREN - (
 	exp := p.parseSQLCondition()
+
+	if exp != nil && p.peekTokenIs(COMMA) {
+		if cond, ok := exp.(*SQLCondition); ok {
+			exp = cond.Expression
+		}
+
+		tuple := &TupleExpression{Token: tok}
+		if tuple.Elements = p.parseExpressionListFrom(exp, RPAREN); tuple.Elements == nil {
+			return nil
+		}
+
+		return tuple
+	}
+
 	if !p.expectPeek(RPAREN) {
 		return nil
 	}
@@ -650,99 +1711,370 @@ func (p *Parser) parseFunctionParameters() []*Identifier {
 }
 
 func (p *Parser) parseStringLiteral() Expression {
+	if p.peekTokenIs(DOT) {
+		return p.parseQualifiedName()
+	}
+
 	return &StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
 }
 
-func (p *Parser) parseSQLFrom() Expression {
-	exp := p.parseExpression(LOWEST)
-	// validate
-	//nolint:gocritic
-	switch tp := exp.(type) {
-	case *InfixExpression:
-		if _, ok := tp.Left.(*IntegerLiteral); ok {
-			p.addError("left node is integer")
+// parseSQLTable parse an item of FROM or JOIN clause: a table name,
+// a sub query like (select ...) or a table function, followed by an optional alias.
+func (p *Parser) parseSQLTable() Expression {
+	exp := &SQLTableExp{Token: p.curToken}
 
-			return exp
+	if p.curSoftKeywordIs(SQLLateral) && p.peekTokenIs(LPAREN, IDENT) {
+		exp.Lateral = true
+		p.nextToken()
+	}
+
+	switch {
+	case p.curTokenIs(LPAREN):
+		if v := p.parseSQLSubSelect(); v != nil {
+			exp.Table = v
+		}
+	case p.curTokenIs(IDENT) && p.peekTokenIs(LPAREN):
+		if v := p.parseSQLTableFunction(); v != nil {
+			exp.Table = v
 		}
+	case p.curTokenIs(IDENT, STRING, QIDENT):
+		exp.Table = p.parseSQLTableName()
+	default:
+		p.addError(fmt.Sprintf("unexpected %s in table reference", p.curToken.Type))
+	}
+
+	if exp.Table == nil {
+		return nil
+	}
+
+	alias, ok := p.parseSQLAlias(true)
+	if !ok {
+		return nil
+	}
 
-		if _, ok := tp.Right.(*IntegerLiteral); ok {
-			p.addError("right node is integer")
+	if exp.Alias = alias; alias != "" && p.peekTokenIs(LPAREN) {
+		p.nextToken()
 
-			return exp
+		exp.Columns = p.parseSQLColumnAliases()
+		if exp.Columns == nil {
+			return nil
 		}
 	}
 
+	for p.peekSoftKeywordIs(SQLUse) || p.peekSoftKeywordIs(SQLForce) || p.peekSoftKeywordIs(SQLIgnore) {
+		p.nextToken()
+
+		hint := p.parseSQLIndexHint()
+		if hint == nil {
+			return nil
+		}
+		exp.Hints = append(exp.Hints, hint)
+	}
+
 	return exp
 }
 
-func (p *Parser) parseSQLColumn() Expression {
-	exp := p.parseExpression(LOWEST)
+// parseSQLIndexHint parse MySQL index hint like: USE INDEX (a, b), FORCE KEY FOR ORDER BY (a), IGNORE INDEX ().
+func (p *Parser) parseSQLIndexHint() *IndexHintExp {
+	exp := &IndexHintExp{Token: p.curToken, Type: LookupSoftKeyword(p.curToken.Literal)}
+
+	if !p.peekSoftKeywordIs(SQLIndex) && !p.peekSoftKeywordIs(SQLKey) {
+		p.peekError(SQLIndex)
+		return nil
+	}
+	p.nextToken()
+
+	if p.peekSoftKeywordIs(SQLFor) {
+		p.nextToken()
 
-	// validate
-	//nolint:gocritic
-	switch tp := exp.(type) {
-	case *InfixExpression:
-		if _, ok := tp.Right.(*IntegerLiteral); ok {
-			p.addError("right node is integer")
+		switch {
+		case p.peekTokenIs(SQLJoin):
+			exp.For = SQLJoin.String()
+		case p.peekTokenIs(SQLOrder, SQLGroup):
+			exp.For = p.peekToken.Type.String() + " " + SQLBy.String()
+			p.nextToken()
 
-			return exp
+			if !p.peekTokenIs(SQLBy) {
+				p.peekError(SQLBy)
+				return nil
+			}
+		default:
+			p.peekError(SQLJoin)
+			return nil
 		}
+		p.nextToken()
+	}
+
+	if !p.expectPeek(LPAREN) {
+		return nil
+	}
+
+	if p.peekTokenIs(RPAREN) {
+		p.nextToken()
+
+		return exp
+	}
+
+	if exp.Indexes = p.parseSQLColumnAliases(); exp.Indexes == nil {
+		return nil
 	}
 
 	return exp
 }
 
-func (p *Parser) parseSQLSource() Expression {
-	stopTokens := []TokenType{
-		COMMA, EOF, SQLFrom, SEMICOLON, SQLWhere, SQLGroup, SQLOrder, SQLLimit, SQLInner, SQLLeft, SQLRight, SQLCross, SQLJoin, SQLOn,
+// parseSQLTableFunction parse table function like: numbers(10), remote('host', db, t).
+func (p *Parser) parseSQLTableFunction() *TableFunction {
+	exp := &TableFunction{Token: p.curToken, Name: p.curToken.Literal}
+	p.nextToken()
+
+	if exp.Arguments = p.parseExpressionList(RPAREN); exp.Arguments == nil {
+		return nil
+	}
+
+	return exp
+}
+
+// parseSQLTableName parse the name of table like: db.table, `db`.`table`.
+func (p *Parser) parseSQLTableName() Expression {
+	exp := p.parseQualifiedName()
+	if _, ok := exp.(*QualifiedName); !ok && exp != nil {
+		p.addError(fmt.Sprintf("expected table name, got %s instead", exp.String()))
+		return nil
 	}
 
-	col := &SQLSource{Token: p.curToken, Value: ""}
-	col.Value += p.curToken.Literal
-	var alias bool
+	return exp
+}
+
+// parseSQLColumnAliases parse list of column aliases like: (a, b, c).
+func (p *Parser) parseSQLColumnAliases() []string {
+	var columns []string
+
+	for {
+		if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+			p.peekError(IDENT)
+
+			return nil
+		}
+		p.nextToken()
+
+		columns = append(columns, p.curToken.Literal)
 
-	for !p.peekTokenIs(stopTokens...) {
+		if !p.peekTokenIs(COMMA) {
+			break
+		}
 		p.nextToken()
+	}
 
-		if p.curTokenIs(SQLAs) {
-			alias = true
-			continue
+	if !p.expectPeek(RPAREN) {
+		return nil
+	}
+
+	return columns
+}
+
+// parseSQLColumn parse an item of select list with an optional alias like: count(*) AS cnt or count(*) cnt.
+func (p *Parser) parseSQLColumn() Expression {
+	tok := p.curToken
+
+	// the alias of the column is not a part of the expression
+	exp := p.parseExpression(ALIAS)
+	if exp == nil {
+		return nil
+	}
+
+	alias, ok := p.parseSQLAlias(false)
+	if !ok {
+		return nil
+	}
+
+	if alias != "" {
+		return &SQLColumnExp{Token: tok, Value: exp, Alias: alias}
+	}
+
+	return exp
+}
+
+// parseSQLAlias parse an optional alias of a column or a table after the current token: AS name or an implicit alias
+// like: count(*) cnt. Soft keywords which can start the next part of the query are never taken as an implicit alias.
+func (p *Parser) parseSQLAlias(table bool) (string, bool) {
+	if p.peekTokenIs(SQLAs) {
+		p.nextToken()
+
+		if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+			p.peekError(IDENT)
+
+			return "", false
+		}
+		p.nextToken()
+
+		return p.curToken.Literal, true
+	}
+
+	if p.peekTokenIs(STRING, QIDENT) || (p.peekTokenIs(IDENT) && !p.peekAliasStop(table)) {
+		p.nextToken()
+
+		return p.curToken.Literal, true
+	}
+
+	return "", true
+}
+
+// peekAliasStop checks that the peek token can start the next part of the query after a column or a table.
+func (p *Parser) peekAliasStop(table bool) bool {
+	tok := LookupSoftKeyword(p.peekToken.Literal)
+
+	return columnAliasStops[tok] || (table && tableAliasStops[tok])
+}
+
+// parseSQLGroupBy parse list of GROUP BY clause with optional WITH ROLLUP, WITH CUBE and WITH TOTALS.
+func (p *Parser) parseSQLGroupBy(stmt *SQLSelectStatement) bool {
+	for {
+		v := p.parseSQLGroup()
+		if v == nil {
+			return false
+		}
+		stmt.Group = append(stmt.Group, v)
+
+		if !p.peekTokenIs(COMMA) {
+			break
+		}
+		p.nextToken()
+		p.nextToken() // next arg
+	}
+	p.nextToken()
+
+	for p.curSoftKeywordIs(SQLWith) {
+		switch {
+		case p.peekSoftKeywordIs(SQLRollup), p.peekSoftKeywordIs(SQLCube):
+			stmt.GroupModifier = LookupSoftKeyword(p.peekToken.Literal)
+		case p.peekSoftKeywordIs(SQLTotals):
+			stmt.WithTotals = true
+		default:
+			p.peekError(SQLTotals)
+			return false
+		}
+
+		p.nextToken()
+		p.nextToken()
+	}
+
+	return true
+}
+
+// parseSQLGroup parse an item of GROUP BY clause: an expression, ROLLUP (...), CUBE (...) or GROUPING SETS (...).
+func (p *Parser) parseSQLGroup() Expression {
+	switch {
+	case (p.curSoftKeywordIs(SQLRollup) || p.curSoftKeywordIs(SQLCube)) && p.peekTokenIs(LPAREN):
+		exp := &GroupingExp{Token: p.curToken, Type: LookupSoftKeyword(p.curToken.Literal)}
+		p.nextToken()
+
+		if exp.Sets = p.parseGroupingSets(); exp.Sets == nil {
+			return nil
+		}
+
+		return exp
+	case p.curSoftKeywordIs(SQLGrouping) && p.peekSoftKeywordIs(SQLSets):
+		exp := &GroupingExp{Token: p.curToken, Type: SQLGroupingSets}
+		p.nextToken()
+
+		if !p.expectPeek(LPAREN) {
+			return nil
 		}
 
-		if alias {
-			if col.Alias == "" && p.curTokenIs(INT) {
-				p.peekError(INT)
+		if exp.Sets = p.parseGroupingSets(); exp.Sets == nil {
+			return nil
+		}
+
+		return exp
+	}
+
+	tok := p.curToken
+
+	exp := p.parseExpression(ALIAS)
+	if exp == nil || !p.peekTokenIs(SQLAs) {
+		return exp
+	}
+	p.nextToken()
+
+	// alias of the group expression like: GROUP BY now() AS date
+	if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+		p.peekError(IDENT)
+		return nil
+	}
+	p.nextToken()
+
+	return &SQLColumnExp{Token: tok, Value: exp, Alias: p.curToken.Literal}
+}
+
+// parseGroupingSets parse list of grouping sets like: ((a, b), a, ()), the current token is '('.
+func (p *Parser) parseGroupingSets() [][]Expression {
+	var sets [][]Expression
+
+	for {
+		p.nextToken()
 
+		if p.curTokenIs(LPAREN) {
+			set := p.parseExpressionList(RPAREN)
+			if set == nil && !p.curTokenIs(RPAREN) {
 				return nil
 			}
-
-			col.Alias += p.curToken.Literal
+			sets = append(sets, set)
 		} else {
-			col.Value += p.curToken.Literal
+			exp := p.parseExpression(LOWEST)
+			if exp == nil {
+				return nil
+			}
+			sets = append(sets, []Expression{exp})
 		}
+
+		if !p.peekTokenIs(COMMA) {
+			break
+		}
+		p.nextToken()
 	}
 
-	p.nextToken()
+	if !p.expectPeek(RPAREN) {
+		return nil
+	}
 
-	return col
+	return sets
 }
 
+// parseSQLOrder parse an item of ORDER BY clause like: name COLLATE utf8_bin DESC NULLS LAST.
 func (p *Parser) parseSQLOrder() Expression {
-	col := &SQLOrderExp{Token: p.curToken, Value: ""}
-	col.Value += p.curToken.Literal
+	col := &SQLOrderExp{Token: p.curToken}
+	if col.Value = p.parseExpression(LOWEST); col.Value == nil {
+		return nil
+	}
 
-	for !p.peekTokenIs(COMMA, EOF, SEMICOLON, SQLLimit) {
+	if p.peekSoftKeywordIs(SQLCollate) {
 		p.nextToken()
 
-		if p.curTokenIs(SQLAsc, SQLDesc) {
-			col.Direction = p.curToken
-			break
+		if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+			p.peekError(IDENT)
+			return nil
 		}
+		p.nextToken()
 
-		col.Value += p.curToken.Literal
+		col.Collate = p.curToken.Literal
 	}
 
-	p.nextToken()
+	if p.peekTokenIs(SQLAsc, SQLDesc) {
+		p.nextToken()
+		col.Direction = p.curToken
+	}
+
+	if p.peekSoftKeywordIs(SQLNulls) {
+		p.nextToken()
+
+		if !p.peekSoftKeywordIs(SQLFirst) && !p.peekSoftKeywordIs(SQLLast) {
+			p.peekError(SQLFirst)
+			return nil
+		}
+		p.nextToken()
+
+		col.Nulls = LookupSoftKeyword(p.curToken.Literal)
+	}
 
 	return col
 }
@@ -761,8 +2093,18 @@ func (p *Parser) parseExpressionList(end TokenType) []Expression {
 	}
 
 	p.nextToken()
-	list = append(list, p.parseExpression(LOWEST))
+
+	return p.parseExpressionListFrom(p.parseExpression(LOWEST), end)
+}
+
+// parseExpressionListFrom parse the rest of the list when the first element is already parsed.
+func (p *Parser) parseExpressionListFrom(first Expression, end TokenType) []Expression {
+	list := []Expression{first}
 	for p.peekTokenIs(COMMA) {
+		if !p.checkListLength(len(list) + 1) {
+			return nil
+		}
+
 		p.nextToken()
 		p.nextToken()
 		list = append(list, p.parseExpression(LOWEST))
@@ -790,7 +2132,13 @@ func (p *Parser) parseSQLSubSelect() Expression {
 		return nil
 	}
 
+	p.subSelects++
 	exp.Select = p.parseSQLSelectStatement()
+	p.subSelects--
+
+	if exp.Select == nil {
+		return nil
+	}
 
 	if !p.curTokenIs(RPAREN) {
 		p.addError("check token )")
@@ -801,6 +2149,36 @@ func (p *Parser) parseSQLSubSelect() Expression {
 	return exp
 }
 
+// parseExistsExpression parse sub query predicate like: EXISTS (select ...).
+func (p *Parser) parseExistsExpression() Expression {
+	exp := &ExistsExpression{Token: p.curToken}
+
+	if !p.expectPeek(LPAREN) {
+		return nil
+	}
+
+	if exp.Select = p.parseRequiredSubSelect(); exp.Select == nil {
+		return nil
+	}
+
+	return exp
+}
+
+// parseRequiredSubSelect parse sub query when the current token is ( and reports an error when it is not a sub query.
+func (p *Parser) parseRequiredSubSelect() *SQLSubSelectExpression {
+	if !p.peekTokenIs(SQLSelect) {
+		p.peekError(SQLSelect)
+		return nil
+	}
+
+	exp, ok := p.parseSQLSubSelect().(*SQLSubSelectExpression)
+	if !ok {
+		return nil
+	}
+
+	return exp
+}
+
 // util
 func trace(s string) string {
 	if showEnteringLeaving {
@@ -1,18 +1,36 @@
 package sqlcmp
 
+import "fmt"
+
+// negatedOperators maps an operator to its negated form used after NOT.
+var negatedOperators = map[TokenType]TokenType{
+	SQLLike:   SQLNotLike,
+	SQLILike:  SQLNotILike,
+	SQLRLike:  SQLNotRLike,
+	SQLRegexp: SQLNotRegexp,
+}
+
 func (p *Parser) parseInfixBetweenExpression(left Expression) Expression {
+	return p.parseBetween(left, false)
+}
+
+func (p *Parser) parseBetween(left Expression, not bool) Expression {
 	if !p.curTokenIs(SQLBetween) {
 		p.addError("check Between")
 		return nil
 	}
 
 	exp := &BetweenExpression{
-		Token: p.curToken, Column: left,
+		Token: p.curToken, Column: left, Not: not,
 	}
 
 	p.nextToken() // skip between
 
-	exp.From = p.parseIdentifier()
+	// the bounds bind stronger than AND between them.
+	precedence := p.precedence(SQLBetween)
+	if exp.From = p.parseExpression(precedence); exp.From == nil {
+		return nil
+	}
 
 	if !p.peekTokenIs(SQLAnd) {
 		p.addError("check And")
@@ -20,12 +38,19 @@ func (p *Parser) parseInfixBetweenExpression(left Expression) Expression {
 	}
 	p.nextToken()
 	p.nextToken()
-	exp.To = p.parseIdentifier()
+
+	if exp.To = p.parseExpression(precedence); exp.To == nil {
+		return nil
+	}
 
 	return exp
 }
 
 func (p *Parser) parseInfixInExpression(left Expression) Expression {
+	return p.parseIn(left, false)
+}
+
+func (p *Parser) parseIn(left Expression, not bool) Expression {
 	if !p.curTokenIs(SQLIn) {
 		p.addError("check IN")
 		return nil
@@ -33,13 +58,92 @@ func (p *Parser) parseInfixInExpression(left Expression) Expression {
 	if !p.expectPeek(LPAREN) {
 		return nil
 	}
-	exp := &InExpression{Token: p.curToken, Column: left}
+	exp := &InExpression{Token: p.curToken, Column: left, Not: not}
+
+	if p.peekTokenIs(SQLSelect) {
+		sub := p.parseSQLSubSelect()
+		if sub == nil {
+			return nil
+		}
+		exp.Arguments = []Expression{sub}
+
+		return exp
+	}
 
 	exp.Arguments = p.parseExpressionList(RPAREN)
 
 	return exp
 }
 
+// parseInfixNotExpression parse negated predicates like: NOT IN, NOT LIKE, NOT BETWEEN.
+func (p *Parser) parseInfixNotExpression(left Expression) Expression {
+	tok := p.curToken
+	p.nextToken() // skip not
+
+	switch {
+	case p.curTokenIs(SQLIn):
+		return p.parseIn(left, true)
+	case p.curTokenIs(SQLBetween):
+		return p.parseBetween(left, true)
+	case p.curSoftKeywordIs(SQLSimilar):
+		return p.parseSimilar(tok, left, true)
+	}
+
+	operator, ok := negatedOperators[infixType(p.curToken)]
+	if !ok {
+		p.addError(fmt.Sprintf("unexpected %s after NOT", p.curToken.Type))
+		return nil
+	}
+
+	return p.parseInfixOperator(tok, operator, left)
+}
+
+// parseInfixSimilarExpression parse SQL regular expression match: SIMILAR TO.
+func (p *Parser) parseInfixSimilarExpression(left Expression) Expression {
+	return p.parseSimilar(p.curToken, left, false)
+}
+
+func (p *Parser) parseSimilar(tok Token, left Expression, not bool) Expression {
+	if !p.peekSoftKeywordIs(SQLTo) {
+		p.peekError(SQLTo)
+		return nil
+	}
+	p.nextToken()
+
+	operator := SQLSimilarTo
+	if not {
+		operator = SQLNotSimilarTo
+	}
+
+	return p.parseInfixOperator(tok, operator, left)
+}
+
+// parseInfixIsExpression parse IS [NOT] NULL|TRUE|FALSE.
+func (p *Parser) parseInfixIsExpression(left Expression) Expression {
+	tok := p.curToken
+	operator := SQLIs
+	if p.peekTokenIs(SQLNot) {
+		p.nextToken()
+		operator = SQLIsNot
+	}
+
+	return p.parseInfixOperator(tok, operator, left)
+}
+
+// parseInfixOperator parse right side of the operator which may consist of several tokens.
+func (p *Parser) parseInfixOperator(tok Token, operator TokenType, left Expression) Expression {
+	expression := &InfixExpression{
+		Token:    tok,
+		Operator: operator,
+		Left:     left,
+	}
+	p.nextToken()
+
+	expression.Right = p.parseExpression(p.precedence(tok.Type))
+
+	return expression
+}
+
 func (p *Parser) parseInfixExpression(left Expression) Expression {
 	defer untrace(trace("parseInfixExpression"))
 
@@ -56,12 +160,212 @@ func (p *Parser) parseInfixExpression(left Expression) Expression {
 	return expression
 }
 
+// parseInfixOrExpression parse MySQL || which is logical OR.
+func (p *Parser) parseInfixOrExpression(left Expression) Expression {
+	exp, ok := p.parseInfixExpression(left).(*InfixExpression)
+	if !ok {
+		return nil
+	}
+	exp.Operator = SQLOr
+
+	return exp
+}
+
 func (p *Parser) parseCallExpression(function Expression) Expression {
+	if ident, ok := function.(*Identifier); ok {
+		if exp, ok := p.parseSpecialCall(ident); ok {
+			return exp
+		}
+	}
+
+	if ident, ok := function.(*Identifier); ok && p.peekTokenIs(SQLSelect) {
+		// ANY, SOME and ALL are also names of functions, they are quantifiers only before a sub query.
+		switch LookupSoftKeyword(ident.Value) {
+		case SQLAny, SQLSome, SQLAll:
+			exp := &QuantifiedExpression{Token: ident.Token, Quantifier: LookupSoftKeyword(ident.Value)}
+			if exp.Select = p.parseRequiredSubSelect(); exp.Select == nil {
+				return nil
+			}
+
+			return exp
+		}
+	}
+
 	exp := &CallExpression{Token: p.curToken, Function: function}
 	exp.Arguments = p.parseExpressionList(RPAREN)
+
+	if p.peekSoftKeywordIs(SQLOver) {
+		p.nextToken()
+
+		if exp.Window = p.parseWindowSpec(); exp.Window == nil {
+			return nil
+		}
+	}
+
 	return exp
 }
 
+// parseWindowSpec parse window of analytic function like: OVER w or OVER (PARTITION BY a ORDER BY b).
+func (p *Parser) parseWindowSpec() *WindowSpec {
+	spec := &WindowSpec{Token: p.curToken}
+
+	if p.peekTokenIs(IDENT) {
+		p.nextToken()
+		spec.Name = p.curToken.Literal
+
+		return spec
+	}
+
+	if !p.expectPeek(LPAREN) {
+		return nil
+	}
+
+	return p.parseWindowBody(spec)
+}
+
+// parseWindowBody parse window specification inside the parentheses.
+func (p *Parser) parseWindowBody(spec *WindowSpec) *WindowSpec {
+	p.nextToken() // skip (
+
+	if p.curTokenIs(IDENT) && !p.curSoftKeywordIs(SQLPartition) && p.curFrameUnit() == "" {
+		spec.Name = p.curToken.Literal
+		p.nextToken()
+	}
+
+	if p.curSoftKeywordIs(SQLPartition) {
+		if !p.expectPeek(SQLBy) {
+			return nil
+		}
+		p.nextToken()
+
+		if spec.PartitionBy = p.parseWindowList(p.parseWindowPartition); spec.PartitionBy == nil {
+			return nil
+		}
+	}
+
+	if p.curTokenIs(SQLOrder) {
+		if !p.expectPeek(SQLBy) {
+			return nil
+		}
+		p.nextToken()
+
+		if spec.OrderBy = p.parseWindowList(p.parseSQLOrder); spec.OrderBy == nil {
+			return nil
+		}
+	}
+
+	if unit := p.curFrameUnit(); unit != "" {
+		if spec.Frame = p.parseWindowFrame(unit); spec.Frame == nil {
+			return nil
+		}
+		p.nextToken()
+	}
+
+	if !p.curTokenIs(RPAREN) {
+		p.addError(fmt.Sprintf("expected %s, got %s instead", RPAREN, p.curToken.Type))
+		return nil
+	}
+
+	return spec
+}
+
+// parseWindowList parse comma separated list, the current token is the token after the list.
+func (p *Parser) parseWindowList(parse func() Expression) []Expression {
+	var list []Expression
+
+	for {
+		exp := parse()
+		if exp == nil {
+			return nil
+		}
+		list = append(list, exp)
+		p.nextToken()
+
+		if !p.curTokenIs(COMMA) {
+			return list
+		}
+		p.nextToken()
+	}
+}
+
+func (p *Parser) parseWindowPartition() Expression {
+	return p.parseExpression(LOWEST)
+}
+
+func (p *Parser) curFrameUnit() TokenType {
+	if !p.curTokenIs(IDENT) {
+		return ""
+	}
+
+	switch unit := LookupSoftKeyword(p.curToken.Literal); unit {
+	case SQLRows, SQLRange, SQLGroups:
+		return unit
+	}
+
+	return ""
+}
+
+// parseWindowFrame parse frame like: ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW.
+func (p *Parser) parseWindowFrame(unit TokenType) *WindowFrame {
+	frame := &WindowFrame{Unit: unit}
+	p.nextToken()
+
+	if !p.curTokenIs(SQLBetween) {
+		if frame.Start = p.parseFrameBound(); frame.Start == nil {
+			return nil
+		}
+
+		return frame
+	}
+
+	p.nextToken()
+	if frame.Start = p.parseFrameBound(); frame.Start == nil {
+		return nil
+	}
+
+	if !p.expectPeek(SQLAnd) {
+		return nil
+	}
+	p.nextToken()
+
+	if frame.End = p.parseFrameBound(); frame.End == nil {
+		return nil
+	}
+
+	return frame
+}
+
+func (p *Parser) parseFrameBound() *FrameBound {
+	bound := &FrameBound{}
+
+	switch {
+	case p.curSoftKeywordIs(SQLCurrent):
+		if !p.peekSoftKeywordIs(SQLRow) {
+			p.peekError(SQLRow)
+			return nil
+		}
+		p.nextToken()
+		bound.Direction = SQLCurrent
+
+		return bound
+	case p.curSoftKeywordIs(SQLUnbounded):
+		bound.Unbounded = true
+	default:
+		if bound.Offset = p.parseExpression(LOWEST); bound.Offset == nil {
+			return nil
+		}
+	}
+
+	if !p.peekSoftKeywordIs(SQLPreceding) && !p.peekSoftKeywordIs(SQLFollowing) {
+		p.peekError(SQLPreceding)
+		return nil
+	}
+	p.nextToken()
+	bound.Direction = LookupSoftKeyword(p.curToken.Literal)
+
+	return bound
+}
+
 func (p *Parser) parseIndexExpression(left Expression) Expression {
 	exp := &IndexExpression{Token: p.curToken, Left: left}
 	p.nextToken()
@@ -78,14 +382,77 @@ func (p *Parser) parseInfixDot(left Expression) Expression {
 		return nil
 	}
 
-	if !p.peekTokenIs(STRING, IDENT) {
-		p.addError("next is STRING or IDENT")
+	if !p.peekTokenIs(STRING, IDENT, QIDENT, INT) {
+		p.addError("next is STRING, IDENT or INT")
 		return nil
 	}
 
 	exp := &DotExpression{Token: p.curToken, Left: left}
 	p.nextToken()
-	exp.Right = p.parseIdentifier()
+
+	if p.curTokenIs(INT) {
+		exp.Right = p.parseIntegerLiteral()
+	} else {
+		exp.Right = p.parseQualifiedName()
+	}
+
+	return exp
+}
+
+// parseLambdaExpression parse ClickHouse lambda like: x -> x * 2 or (x, y) -> x + y.
+func (p *Parser) parseLambdaExpression(left Expression) Expression {
+	exp := &LambdaExpression{Token: p.curToken}
+
+	switch tp := left.(type) {
+	case *QualifiedName:
+		name, ok := lambdaParam(tp)
+		if !ok {
+			p.addError(fmt.Sprintf("expected lambda parameter, got %s instead", exprString(tp)))
+			return nil
+		}
+		exp.Params = []string{name}
+	case *TupleExpression:
+		for _, el := range tp.Elements {
+			name, ok := lambdaParam(el)
+			if !ok {
+				p.addError(fmt.Sprintf("expected lambda parameter, got %s instead", exprString(el)))
+				return nil
+			}
+			exp.Params = append(exp.Params, name)
+		}
+	default:
+		p.addError(fmt.Sprintf("expected lambda parameters, got %s instead", exprString(left)))
+		return nil
+	}
+	p.nextToken()
+
+	if exp.Body = p.parseExpression(LOWEST); exp.Body == nil {
+		return nil
+	}
+
+	return exp
+}
+
+// lambdaParam returns the name of lambda parameter, it is a name of one unquoted part like: x.
+func lambdaParam(exp Expression) (string, bool) {
+	name, ok := exp.(*QualifiedName)
+	if !ok || len(name.Parts) != 1 || name.Quoted[0] {
+		return "", false
+	}
+
+	return name.Parts[0], true
+}
+
+// parseInfixAlias parse ClickHouse alias inside an expression like: (a + 1 AS x) * 2.
+func (p *Parser) parseInfixAlias(left Expression) Expression {
+	exp := &AliasExpression{Token: p.curToken, Value: left}
+
+	if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+		p.peekError(IDENT)
+		return nil
+	}
+	p.nextToken()
+	exp.Alias = p.curToken.Literal
 
 	return exp
 }
@@ -0,0 +1,280 @@
+package sqlcmp
+
+import "fmt"
+
+// parseSQLStatement parse SELECT, INSERT or REPLACE statement, other statements like DELETE are reported as errors.
+// The empty query is the empty SELECT.
+func (p *Parser) parseSQLStatement() Statement {
+	if !p.curTokenIs(SQLSelect, EOF) && !p.curInsertStart() {
+		p.addError(fmt.Sprintf("unsupported statement %s", p.curToken.Literal))
+
+		return nil
+	}
+
+	if !p.curInsertStart() {
+		if stmt := p.parseSQLSelectStatement(); stmt != nil {
+			return stmt
+		}
+
+		return nil
+	}
+
+	if stmt := p.parseSQLInsertStatement(); stmt != nil {
+		return stmt
+	}
+
+	return nil
+}
+
+// parseSQLInsertStatement parse {INSERT | REPLACE} [IGNORE] INTO t [AS alias] [(columns)]
+// {VALUES (...), ... | SELECT ... | DEFAULT VALUES} [ON CONFLICT ... | ON DUPLICATE KEY UPDATE ...] [RETURNING ...].
+func (p *Parser) parseSQLInsertStatement() *SQLInsertStatement {
+	stmt := &SQLInsertStatement{Token: p.curToken, Replace: p.curSoftKeywordIs(SQLReplace)}
+
+	p.insert = true
+	defer func() { p.insert = false }()
+
+	if p.peekSoftKeywordIs(SQLIgnore) {
+		stmt.Ignore = true
+		p.nextToken()
+	}
+
+	if !p.peekSoftKeywordIs(SQLInto) {
+		p.peekError(SQLInto)
+		return nil
+	}
+	p.nextToken()
+
+	if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+		p.peekError(IDENT)
+		return nil
+	}
+	p.nextToken()
+
+	if stmt.Table = p.parseSQLTableName(); stmt.Table == nil {
+		return nil
+	}
+
+	alias, ok := p.parseSQLAlias(true)
+	if !ok {
+		return nil
+	}
+	stmt.Alias = alias
+
+	if p.peekTokenIs(LPAREN) {
+		p.nextToken()
+
+		if stmt.Columns = p.parseSQLColumnAliases(); stmt.Columns == nil {
+			return nil
+		}
+	}
+	p.nextToken()
+
+	if !p.parseSQLInsertSource(stmt) {
+		return nil
+	}
+
+	if p.curTokenIs(SQLOn) && p.peekSoftKeywordIs(SQLConflict) {
+		if stmt.OnConflict = p.parseSQLOnConflict(); stmt.OnConflict == nil {
+			return nil
+		}
+	}
+
+	if p.curTokenIs(SQLOn) && p.peekSoftKeywordIs(SQLDuplicate) {
+		if stmt.OnDuplicate = p.parseSQLOnDuplicateKey(); stmt.OnDuplicate == nil {
+			return nil
+		}
+	}
+
+	if p.curSoftKeywordIs(SQLReturning) {
+		p.nextToken()
+
+		if stmt.Returning = p.parseSQLReturning(); stmt.Returning == nil {
+			return nil
+		}
+	}
+
+	if !p.curTokenIs(SEMICOLON, EOF) {
+		p.addError(fmt.Sprintf("unexpected %s at the end of query", p.curToken.Literal))
+
+		return nil
+	}
+
+	if !p.peekTokenIs(SEMICOLON, EOF) {
+		p.peekError(SEMICOLON)
+
+		return nil
+	}
+
+	return stmt
+}
+
+// parseSQLInsertSource parse VALUES, DEFAULT VALUES or SELECT, the current token is the token after the source.
+func (p *Parser) parseSQLInsertSource(stmt *SQLInsertStatement) bool {
+	switch {
+	case p.curSoftKeywordIs(SQLValues):
+		return p.parseSQLValues(stmt)
+	case p.curSoftKeywordIs(SQLDefault) && p.peekSoftKeywordIs(SQLValues):
+		stmt.DefaultValues = true
+		p.nextToken()
+		p.nextToken()
+
+		return true
+	case p.curTokenIs(SQLSelect):
+		stmt.Select = p.parseSQLSelectStatement()
+
+		return stmt.Select != nil
+	}
+
+	p.addError(fmt.Sprintf("expected %s or %s, got %s instead", SQLValues, SQLSelect, p.curToken.Literal))
+
+	return false
+}
+
+// parseSQLValues parse rows of VALUES clause like: VALUES (1, 'a'), (2, 'b').
+func (p *Parser) parseSQLValues(stmt *SQLInsertStatement) bool {
+	for {
+		if !p.expectPeek(LPAREN) {
+			return false
+		}
+
+		row := p.parseExpressionList(RPAREN)
+		if len(row) == 0 {
+			p.addError("expected values of row")
+			return false
+		}
+		stmt.Values = append(stmt.Values, row)
+
+		if !p.peekTokenIs(COMMA) {
+			break
+		}
+
+		if !p.checkListLength(len(stmt.Values) + 1) {
+			return false
+		}
+		p.nextToken()
+	}
+	p.nextToken()
+
+	return true
+}
+
+// parseSQLOnConflict parse ON CONFLICT [(target) | ON CONSTRAINT name] DO {NOTHING | UPDATE SET ... [WHERE ...]}.
+// The current token is the token after the clause.
+func (p *Parser) parseSQLOnConflict() *OnConflictExp {
+	exp := &OnConflictExp{Token: p.curToken}
+	p.nextToken() // skip on
+
+	switch {
+	case p.peekTokenIs(LPAREN):
+		p.nextToken()
+
+		if exp.Target = p.parseExpressionList(RPAREN); len(exp.Target) == 0 {
+			p.addError("expected conflict target")
+			return nil
+		}
+	case p.peekTokenIs(SQLOn):
+		p.nextToken()
+
+		if !p.peekSoftKeywordIs(SQLConstraint) {
+			p.peekError(SQLConstraint)
+			return nil
+		}
+		p.nextToken()
+
+		if !p.peekTokenIs(IDENT, STRING, QIDENT) {
+			p.peekError(IDENT)
+			return nil
+		}
+		p.nextToken()
+		exp.Constraint = p.curToken.Literal
+	}
+
+	if !p.peekSoftKeywordIs(SQLDo) {
+		p.peekError(SQLDo)
+		return nil
+	}
+	p.nextToken()
+	p.nextToken()
+
+	switch {
+	case p.curSoftKeywordIs(SQLNothing):
+		p.nextToken()
+
+		return exp
+	case !p.curSoftKeywordIs(SQLUpdate):
+		p.addError(fmt.Sprintf("expected %s or %s, got %s instead", SQLNothing, SQLUpdate, p.curToken.Literal))
+		return nil
+	}
+	exp.Update = true
+
+	if !p.expectPeek(SETS) {
+		return nil
+	}
+	p.nextToken()
+
+	if exp.Set = p.parseSQLList(); exp.Set == nil {
+		return nil
+	}
+
+	if p.curTokenIs(SQLWhere) {
+		exp.Cond = p.parseSQLConditions()
+	}
+
+	return exp
+}
+
+// parseSQLOnDuplicateKey parse ON DUPLICATE KEY UPDATE a = VALUES(a), ..., the current token is the token after the clause.
+func (p *Parser) parseSQLOnDuplicateKey() *OnDuplicateKeyExp {
+	exp := &OnDuplicateKeyExp{Token: p.curToken}
+
+	for _, t := range []TokenType{SQLDuplicate, SQLKey, SQLUpdate} {
+		if !p.peekSoftKeywordIs(t) {
+			p.peekError(t)
+			return nil
+		}
+		p.nextToken()
+	}
+	p.nextToken()
+
+	if exp.Set = p.parseSQLList(); exp.Set == nil {
+		return nil
+	}
+
+	return exp
+}
+
+// parseSQLReturning parse list of RETURNING clause, the current token is the token after the list.
+func (p *Parser) parseSQLReturning() []Expression {
+	var list []Expression
+
+	for {
+		col := p.parseSQLColumn()
+		if col == nil {
+			return nil
+		}
+		list = append(list, col)
+		p.nextToken()
+
+		if !p.curTokenIs(COMMA) {
+			return list
+		}
+		p.nextToken()
+	}
+}
+
+// curInsertStart checks that the current token starts INSERT or REPLACE statement.
+func (p *Parser) curInsertStart() bool {
+	return p.curSoftKeywordIs(SQLInsert) || (p.curSoftKeywordIs(SQLReplace) && p.peekSoftKeywordIs(SQLInto))
+}
+
+// curInsertTail checks that the current token starts the clause of INSERT after SELECT:
+// ON CONFLICT, ON DUPLICATE KEY or RETURNING.
+func (p *Parser) curInsertTail() bool {
+	if !p.insert {
+		return false
+	}
+
+	return p.curSoftKeywordIs(SQLReturning) ||
+		(p.curTokenIs(SQLOn) && (p.peekSoftKeywordIs(SQLConflict) || p.peekSoftKeywordIs(SQLDuplicate)))
+}
@@ -25,14 +25,14 @@ func TestParser_parseSQLCond(t *testing.T) {
 						Token: Token{Type: SQLAnd, Literal: "and"},
 						Left: &InfixExpression{
 							Token:    Token{Type: ASSIGN, Literal: "="},
-							Left:     &Identifier{Token: Token{Type: IDENT, Literal: "t1"}, Value: "t1.key"},
+							Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "t1"}, Parts: []string{"t1", "key"}, Quoted: []bool{false, false}},
 							Operator: ASSIGN,
-							Right:    &Identifier{Token: Token{Type: IDENT, Literal: "t3"}, Value: "t3.key"},
+							Right:    &QualifiedName{Token: Token{Type: IDENT, Literal: "t3"}, Parts: []string{"t3", "key"}, Quoted: []bool{false, false}},
 						},
 						Operator: SQLAnd,
 						Right: &InfixExpression{
 							Token:    Token{Type: GT, Literal: ">"},
-							Left:     &Identifier{Token: Token{Type: IDENT, Literal: "t3"}, Value: "t3.date"},
+							Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "t3"}, Parts: []string{"t3", "date"}, Quoted: []bool{false, false}},
 							Operator: GT,
 							Right: &CallExpression{
 								Token: Token{Type: LPAREN, Literal: "("},
@@ -46,10 +46,42 @@ func TestParser_parseSQLCond(t *testing.T) {
 				},
 			},
 		},
+		{
+			input:         "(a, b) in ((1, 2), (3, 4))",
+			expectedQuery: "(a, b) IN ((1, 2), (3, 4))",
+			expectedValue: &SQLCondition{
+				Expression: &InExpression{
+					Token: Token{Type: LPAREN, Literal: "("},
+					Column: &TupleExpression{
+						Token: Token{Type: LPAREN, Literal: "("},
+						Elements: []Expression{
+							&QualifiedName{Token: Token{Type: IDENT, Literal: "a"}, Parts: []string{"a"}, Quoted: []bool{false}},
+							&QualifiedName{Token: Token{Type: IDENT, Literal: "b"}, Parts: []string{"b"}, Quoted: []bool{false}},
+						},
+					},
+					Arguments: []Expression{
+						&TupleExpression{
+							Token: Token{Type: LPAREN, Literal: "("},
+							Elements: []Expression{
+								&IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
+								&IntegerLiteral{Token: Token{Type: INT, Literal: "2"}, Value: 2},
+							},
+						},
+						&TupleExpression{
+							Token: Token{Type: LPAREN, Literal: "("},
+							Elements: []Expression{
+								&IntegerLiteral{Token: Token{Type: INT, Literal: "3"}, Value: 3},
+								&IntegerLiteral{Token: Token{Type: INT, Literal: "4"}, Value: 4},
+							},
+						},
+					},
+				},
+			},
+		},
 		{input: "id=1", expectedQuery: "(id = 1)", expectedValue: &SQLCondition{
 			Expression: &InfixExpression{
 				Token:    Token{Type: ASSIGN, Literal: ASSIGN.String()},
-				Left:     &Identifier{Token: Token{Type: IDENT, Literal: "id"}, Value: "id"},
+				Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "id"}, Parts: []string{"id"}, Quoted: []bool{false}},
 				Operator: ASSIGN,
 				Right:    &IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
 			},
@@ -57,7 +89,7 @@ func TestParser_parseSQLCond(t *testing.T) {
 		{input: "t.id=1", expectedQuery: "(t.id = 1)", expectedValue: &SQLCondition{
 			Expression: &InfixExpression{
 				Token:    Token{Type: ASSIGN, Literal: ASSIGN.String()},
-				Left:     &Identifier{Token: Token{Type: IDENT, Literal: "t"}, Value: "t.id"},
+				Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "t"}, Parts: []string{"t", "id"}, Quoted: []bool{false, false}},
 				Operator: ASSIGN,
 				Right:    &IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
 			},
@@ -66,7 +98,7 @@ func TestParser_parseSQLCond(t *testing.T) {
 			input: "id>100", expectedQuery: "(id > 100)", expectedValue: &SQLCondition{
 				Expression: &InfixExpression{
 					Token:    Token{Type: GT, Literal: ">"},
-					Left:     &Identifier{Token: Token{Type: IDENT, Literal: "id"}, Value: "id"},
+					Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "id"}, Parts: []string{"id"}, Quoted: []bool{false}},
 					Operator: GT,
 					Right:    &IntegerLiteral{Token: Token{Type: INT, Literal: "100"}, Value: 100},
 				},
@@ -76,7 +108,7 @@ func TestParser_parseSQLCond(t *testing.T) {
 			input: "name = 'test*'", expectedQuery: "(name = test*)", expectedValue: &SQLCondition{
 				Expression: &InfixExpression{
 					Token:    Token{Type: ASSIGN, Literal: "="},
-					Left:     &Identifier{Token: Token{Type: IDENT, Literal: "name"}, Value: "name"},
+					Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "name"}, Parts: []string{"name"}, Quoted: []bool{false}},
 					Operator: ASSIGN,
 					Right:    &StringLiteral{Token: Token{Type: STRING, Literal: "test*"}, Value: "test*"},
 				},
@@ -87,7 +119,7 @@ func TestParser_parseSQLCond(t *testing.T) {
 				Expression: &SQLCondition{
 					Expression: &InfixExpression{
 						Token:    Token{Type: ASSIGN, Literal: "="},
-						Left:     &Identifier{Token: Token{Type: IDENT, Literal: "name"}, Value: "name"},
+						Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "name"}, Parts: []string{"name"}, Quoted: []bool{false}},
 						Operator: ASSIGN,
 						Right:    &StringLiteral{Token: Token{Type: STRING, Literal: "test*"}, Value: "test*"},
 					},
@@ -101,14 +133,14 @@ func TestParser_parseSQLCond(t *testing.T) {
 					Token: Token{Type: SQLAnd, Literal: "and"},
 					Left: &InfixExpression{
 						Token:    Token{Type: ASSIGN, Literal: ASSIGN.String()},
-						Left:     &Identifier{Token: Token{Type: IDENT, Literal: "x"}, Value: "x"},
+						Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "x"}, Parts: []string{"x"}, Quoted: []bool{false}},
 						Operator: ASSIGN,
 						Right:    &IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
 					},
 					Operator: SQLAnd,
 					Right: &InfixExpression{
 						Token:    Token{Type: ASSIGN, Literal: ASSIGN.String()},
-						Left:     &Identifier{Token: Token{Type: IDENT, Literal: "y"}, Value: "y"},
+						Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "y"}, Parts: []string{"y"}, Quoted: []bool{false}},
 						Operator: ASSIGN,
 						Right:    &IntegerLiteral{Token: Token{Type: INT, Literal: "2"}, Value: 2},
 					},
@@ -122,7 +154,7 @@ func TestParser_parseSQLCond(t *testing.T) {
 				&SQLCondition{
 					Expression: &InfixExpression{
 						Token:    Token{Type: SQLLike, Literal: "like"},
-						Left:     &Identifier{Token: Token{Type: IDENT, Literal: "email"}, Value: "email"},
+						Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "email"}, Parts: []string{"email"}, Quoted: []bool{false}},
 						Operator: SQLLike,
 						Right: &StringLiteral{
 							Token: Token{Type: STRING, Literal: "%abc%"},
@@ -134,16 +166,16 @@ func TestParser_parseSQLCond(t *testing.T) {
 		},
 		{
 			input:         "date between '2023-10-01' and '2023-10-15'",
-			expectedQuery: "date BETWEEN 2023-10-01 AND 2023-10-15",
+			expectedQuery: "(date BETWEEN 2023-10-01 AND 2023-10-15)",
 			expectedValue: &SQLCondition{
 				Expression: &BetweenExpression{
 					Token:  Token{Type: SQLBetween, Literal: "between"},
-					Column: &Identifier{Token: Token{Type: IDENT, Literal: "date"}, Value: "date"},
-					From: &Identifier{
+					Column: &QualifiedName{Token: Token{Type: IDENT, Literal: "date"}, Parts: []string{"date"}, Quoted: []bool{false}},
+					From: &StringLiteral{
 						Token: Token{Type: STRING, Literal: "2023-10-01"},
 						Value: "2023-10-01",
 					},
-					To: &Identifier{
+					To: &StringLiteral{
 						Token: Token{Type: STRING, Literal: "2023-10-15"},
 						Value: "2023-10-15",
 					},
@@ -152,18 +184,18 @@ func TestParser_parseSQLCond(t *testing.T) {
 		},
 		{
 			input:         "range between 10 and 999",
-			expectedQuery: "range BETWEEN 10 AND 999",
+			expectedQuery: "(range BETWEEN 10 AND 999)",
 			expectedValue: &SQLCondition{
 				Expression: &BetweenExpression{
 					Token:  Token{Type: SQLBetween, Literal: "between"},
-					Column: &Identifier{Token: Token{Type: IDENT, Literal: "range"}, Value: "range"},
-					From: &Identifier{
+					Column: &QualifiedName{Token: Token{Type: IDENT, Literal: "range"}, Parts: []string{"range"}, Quoted: []bool{false}},
+					From: &IntegerLiteral{
 						Token: Token{Type: INT, Literal: "10"},
-						Value: "10",
+						Value: 10,
 					},
-					To: &Identifier{
+					To: &IntegerLiteral{
 						Token: Token{Type: INT, Literal: "999"},
-						Value: "999",
+						Value: 999,
 					},
 				},
 			},
@@ -179,10 +211,10 @@ func TestParser_parseSQLCond(t *testing.T) {
 						Expression: &InfixExpression{
 							Token:    Token{Type: NotEq, Literal: "!="},
 							Operator: NotEq,
-							Left: &DotExpression{
-								Token: Token{Type: DOT, Literal: "."},
-								Left:  &StringLiteral{Token: Token{Type: STRING, Literal: "u"}, Value: "u"},
-								Right: &Identifier{Token: Token{Type: STRING, Literal: "status"}, Value: "status"},
+							Left: &QualifiedName{
+								Token:  Token{Type: QIDENT, Literal: "u"},
+								Parts:  []string{"u", "status"},
+								Quoted: []bool{true, true},
 							},
 							Right: &StringLiteral{Token: Token{Type: STRING, Literal: "deleted"}, Value: "deleted"},
 						},
@@ -190,10 +222,10 @@ func TestParser_parseSQLCond(t *testing.T) {
 					Right: &SQLCondition{
 						Expression: &InExpression{
 							Token: Token{Type: LPAREN, Literal: "("},
-							Column: &DotExpression{
-								Token: Token{Type: DOT, Literal: "."},
-								Left:  &StringLiteral{Token: Token{Type: STRING, Literal: "a"}, Value: "a"},
-								Right: &Identifier{Token: Token{Type: STRING, Literal: "id"}, Value: "id"},
+							Column: &QualifiedName{
+								Token:  Token{Type: QIDENT, Literal: "a"},
+								Parts:  []string{"a", "id"},
+								Quoted: []bool{true, true},
 							},
 							Arguments: []Expression{
 								&IntegerLiteral{
@@ -230,76 +262,63 @@ func TestParser_parseSQLColumns(t *testing.T) {
 		{
 			input:         "date",
 			expectedQuery: "date",
-			expectedExp: &Identifier{
-				Token: Token{Type: IDENT, Literal: "date"},
-				Value: "date",
+			expectedExp: &QualifiedName{
+				Token:  Token{Type: IDENT, Literal: "date"},
+				Parts:  []string{"date"},
+				Quoted: []bool{false},
 			},
 		},
 		{
 			input:         "now() as date",
 			expectedQuery: "now() AS date",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "as"},
-				Operator: SQLAs,
-				Left: &CallExpression{
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "now"},
+				Value: &CallExpression{
 					Token: Token{Type: LPAREN, Literal: "("},
 					Function: &Identifier{
 						Token: Token{Type: IDENT, Literal: "now"},
 						Value: "now",
 					},
 				},
-				Right: &Identifier{
-					Token: Token{Type: IDENT, Literal: "date"},
-					Value: "date",
-				},
+				Alias: "date",
 			},
 		},
 		{
 			input:         "db.table as t1",
 			expectedQuery: "db.table AS t1",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "as"},
-				Operator: SQLAs,
-				Left: &Identifier{
-					Token: Token{Type: IDENT, Literal: "db"},
-					Value: "db.table",
-				},
-				Right: &Identifier{
-					Token: Token{Type: IDENT, Literal: "t1"},
-					Value: "t1",
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "db"},
+				Value: &QualifiedName{
+					Token:  Token{Type: IDENT, Literal: "db"},
+					Parts:  []string{"db", "table"},
+					Quoted: []bool{false, false},
 				},
+				Alias: "t1",
 			},
 		},
 		{
 			input:         "name as nm",
 			expectedQuery: "name AS nm",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "as"},
-				Operator: SQLAs,
-				Left: &Identifier{
-					Token: Token{Type: IDENT, Literal: "name"},
-					Value: "name",
-				},
-				Right: &Identifier{
-					Token: Token{Type: IDENT, Literal: "nm"},
-					Value: "nm",
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "name"},
+				Value: &QualifiedName{
+					Token:  Token{Type: IDENT, Literal: "name"},
+					Parts:  []string{"name"},
+					Quoted: []bool{false},
 				},
+				Alias: "nm",
 			},
 		},
 		{
 			input:         "1001 as ID",
 			expectedQuery: "1001 AS ID",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "as"},
-				Operator: SQLAs,
-				Left: &IntegerLiteral{
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: INT, Literal: "1001"},
+				Value: &IntegerLiteral{
 					Token: Token{Type: INT, Literal: "1001"},
 					Value: 1001,
 				},
-				Right: &Identifier{
-					Token: Token{Type: IDENT, Literal: "ID"},
-					Value: "ID",
-				},
+				Alias: "ID",
 			},
 		},
 		// all
@@ -314,59 +333,53 @@ func TestParser_parseSQLColumns(t *testing.T) {
 		{
 			input:         "t1.*",
 			expectedQuery: "t1.*",
-			expectedExp: &Identifier{
-				Token: Token{Type: IDENT, Literal: "t1"},
-				Value: "t1.*",
+			expectedExp: &QualifiedName{
+				Token:  Token{Type: IDENT, Literal: "t1"},
+				Parts:  []string{"t1", "*"},
+				Quoted: []bool{false, false},
 			},
 		},
 		{
 			input:         "`date` as `dt`",
 			expectedQuery: "date AS dt",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "as"},
-				Operator: SQLAs,
-				Left: &StringLiteral{
-					Token: Token{Type: STRING, Literal: "date"},
-					Value: "date",
-				},
-				Right: &StringLiteral{
-					Token: Token{Type: STRING, Literal: "dt"},
-					Value: "dt",
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: QIDENT, Literal: "date"},
+				Value: &QualifiedName{
+					Token:  Token{Type: QIDENT, Literal: "date"},
+					Parts:  []string{"date"},
+					Quoted: []bool{true},
 				},
+				Alias: "dt",
 			},
 		},
 		{
 			input:         " sum(price) AS amount",
 			expectedQuery: "sum(price) AS amount",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "AS"},
-				Operator: SQLAs,
-				Left: &CallExpression{
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "sum"},
+				Value: &CallExpression{
 					Token: Token{Type: LPAREN, Literal: "("},
 					Function: &Identifier{
 						Token: Token{Type: IDENT, Literal: "sum"},
 						Value: "sum",
 					},
 					Arguments: []Expression{
-						&Identifier{
-							Token: Token{Type: IDENT, Literal: "price"},
-							Value: "price",
+						&QualifiedName{
+							Token:  Token{Type: IDENT, Literal: "price"},
+							Parts:  []string{"price"},
+							Quoted: []bool{false},
 						},
 					},
 				},
-				Right: &Identifier{
-					Token: Token{Type: IDENT, Literal: "amount"},
-					Value: "amount",
-				},
+				Alias: "amount",
 			},
 		},
 		{
 			input:         "(select 1) as id",
 			expectedQuery: "(SELECT 1) AS id",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "as"},
-				Operator: SQLAs,
-				Left: &SQLSubSelectExpression{
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: LPAREN, Literal: "("},
+				Value: &SQLSubSelectExpression{
 					Token: Token{Type: LPAREN, Literal: "("},
 					Select: &SQLSelectStatement{
 						Token: Token{Type: SQLSelect, Literal: "select"},
@@ -378,38 +391,36 @@ func TestParser_parseSQLColumns(t *testing.T) {
 						},
 					},
 				},
-				Right: &Identifier{
-					Token: Token{Type: IDENT, Literal: "id"},
-					Value: "id",
-				},
+				Alias: "id",
 			},
 		},
 		{
 			// CH dialect
 			input:         "sumIf(`count`, type=10 OR type>=100) AS `value`",
 			expectedQuery: "sumIf(count, ((type = 10) OR (type >= 100))) AS value",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "AS"},
-				Operator: "AS",
-				Left: &CallExpression{
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "sumIf"},
+				Value: &CallExpression{
 					Token: Token{Type: LPAREN, Literal: "("},
 					Function: &Identifier{
 						Token: Token{Type: IDENT, Literal: "sumIf"},
 						Value: "sumIf",
 					},
 					Arguments: []Expression{
-						&StringLiteral{
-							Token: Token{Type: STRING, Literal: "count"},
-							Value: "count",
+						&QualifiedName{
+							Token:  Token{Type: QIDENT, Literal: "count"},
+							Parts:  []string{"count"},
+							Quoted: []bool{true},
 						},
 						&InfixExpression{
 							Token:    Token{Type: SQLOr, Literal: "OR"},
 							Operator: "OR",
 							Left: &InfixExpression{
 								Token: Token{Type: ASSIGN, Literal: "="},
-								Left: &Identifier{
-									Token: Token{Type: IDENT, Literal: "type"},
-									Value: "type",
+								Left: &QualifiedName{
+									Token:  Token{Type: IDENT, Literal: "type"},
+									Parts:  []string{"type"},
+									Quoted: []bool{false},
 								},
 								Operator: ASSIGN,
 								Right: &IntegerLiteral{
@@ -419,9 +430,10 @@ func TestParser_parseSQLColumns(t *testing.T) {
 							},
 							Right: &InfixExpression{
 								Token: Token{Type: GtOrEg, Literal: ">="},
-								Left: &Identifier{
-									Token: Token{Type: IDENT, Literal: "type"},
-									Value: "type",
+								Left: &QualifiedName{
+									Token:  Token{Type: IDENT, Literal: "type"},
+									Parts:  []string{"type"},
+									Quoted: []bool{false},
 								},
 								Operator: GtOrEg,
 								Right: &IntegerLiteral{
@@ -432,44 +444,120 @@ func TestParser_parseSQLColumns(t *testing.T) {
 						},
 					},
 				},
-				Right: &StringLiteral{
-					Token: Token{Type: STRING, Literal: "value"},
-					Value: "value",
+				Alias: "value",
+			},
+		},
+		{
+			input:         "count(*) cnt",
+			expectedQuery: "count(*) AS cnt",
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "count"},
+				Value: &CallExpression{
+					Token: Token{Type: LPAREN, Literal: "("},
+					Function: &Identifier{
+						Token: Token{Type: IDENT, Literal: "count"},
+						Value: "count",
+					},
+					Arguments: []Expression{
+						&Identifier{
+							Token: Token{Type: IDENT, Literal: "*"},
+							Value: "*",
+						},
+					},
 				},
+				Alias: "cnt",
 			},
 		},
 		// cast
 		{
 			input:         "CAST(u.smb AS unsigned) AS smb",
 			expectedQuery: "CAST(u.smb AS unsigned) AS smb",
-			expectedExp: &InfixExpression{
-				Token:    Token{Type: SQLAs, Literal: "AS"},
-				Operator: "AS",
-				Left: &CallExpression{
-					Token: Token{Type: LPAREN, Literal: "("},
-					Function: &Identifier{
-						Token: Token{Type: IDENT, Literal: "CAST"},
-						Value: "CAST",
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "CAST"},
+				Value: &CastExpression{
+					Token: Token{Type: IDENT, Literal: "CAST"},
+					Value: &QualifiedName{
+						Token:  Token{Type: IDENT, Literal: "u"},
+						Parts:  []string{"u", "smb"},
+						Quoted: []bool{false, false},
 					},
-					Arguments: []Expression{
-						&InfixExpression{
-							Token:    Token{Type: SQLAs, Literal: "AS"},
-							Operator: "AS",
-							Left: &Identifier{
-								Token: Token{Type: IDENT, Literal: "u"},
-								Value: "u.smb",
-							},
-							Right: &Identifier{
-								Token: Token{Type: IDENT, Literal: "unsigned"},
-								Value: "unsigned",
-							},
+					Type:   "unsigned",
+					Syntax: CastFunction,
+				},
+				Alias: "smb",
+			},
+		},
+		// ClickHouse
+		{
+			input:         "arrayMap(x -> x * 2, arr)",
+			expectedQuery: "arrayMap(x -> (x * 2), arr)",
+			expectedExp: &CallExpression{
+				Token: Token{Type: LPAREN, Literal: "("},
+				Function: &Identifier{
+					Token: Token{Type: IDENT, Literal: "arrayMap"},
+					Value: "arrayMap",
+				},
+				Arguments: []Expression{
+					&LambdaExpression{
+						Token:  Token{Type: ARROW, Literal: "->"},
+						Params: []string{"x"},
+						Body: &InfixExpression{
+							Token:    Token{Type: ASTERISK, Literal: "*"},
+							Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "x"}, Parts: []string{"x"}, Quoted: []bool{false}},
+							Operator: "*",
+							Right:    &IntegerLiteral{Token: Token{Type: INT, Literal: "2"}, Value: 2},
 						},
 					},
+					&QualifiedName{Token: Token{Type: IDENT, Literal: "arr"}, Parts: []string{"arr"}, Quoted: []bool{false}},
 				},
-				Right: &Identifier{
-					Token: Token{Type: IDENT, Literal: "smb"},
-					Value: "smb",
+			},
+		},
+		{
+			input:         "t.1 AS first",
+			expectedQuery: "t.1 AS first",
+			expectedExp: &SQLColumnExp{
+				Token: Token{Type: IDENT, Literal: "t"},
+				Value: &DotExpression{
+					Token: Token{Type: DOT, Literal: "."},
+					Left:  &QualifiedName{Token: Token{Type: IDENT, Literal: "t"}, Parts: []string{"t"}, Quoted: []bool{false}},
+					Right: &IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
+				},
+				Alias: "first",
+			},
+		},
+		{
+			input:         "db.t.1.2",
+			expectedQuery: "db.t.1.2",
+			expectedExp: &DotExpression{
+				Token: Token{Type: DOT, Literal: "."},
+				Left: &DotExpression{
+					Token: Token{Type: DOT, Literal: "."},
+					Left: &QualifiedName{
+						Token:  Token{Type: IDENT, Literal: "db"},
+						Parts:  []string{"db", "t"},
+						Quoted: []bool{false, false},
+					},
+					Right: &IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
 				},
+				Right: &IntegerLiteral{Token: Token{Type: INT, Literal: "2"}, Value: 2},
+			},
+		},
+		{
+			input:         "c.s.t.*",
+			expectedQuery: "c.s.t.*",
+			expectedExp: &QualifiedName{
+				Token:  Token{Type: IDENT, Literal: "c"},
+				Parts:  []string{"c", "s", "t", "*"},
+				Quoted: []bool{false, false, false, false},
+			},
+		},
+		{
+			input:         "m['key']",
+			expectedQuery: "(m[key])",
+			expectedExp: &IndexExpression{
+				Token: Token{Type: LBRACKET, Literal: "["},
+				Left:  &QualifiedName{Token: Token{Type: IDENT, Literal: "m"}, Parts: []string{"m"}, Quoted: []bool{false}},
+				Index: &StringLiteral{Token: Token{Type: STRING, Literal: "key"}, Value: "key"},
 			},
 		},
 	}
@@ -485,7 +573,7 @@ func TestParser_parseSQLColumns(t *testing.T) {
 	}
 }
 
-func TestParser_parseSQLSource(t *testing.T) {
+func TestParser_parseSQLGroup(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -496,44 +584,244 @@ func TestParser_parseSQLSource(t *testing.T) {
 		{
 			input:         "date",
 			expectedQuery: "date",
-			expectedExp: &SQLSource{
-				Token: Token{Type: IDENT, Literal: "date"},
-				Value: "date",
+			expectedExp:   &QualifiedName{Token: Token{Type: IDENT, Literal: "date"}, Parts: []string{"date"}, Quoted: []bool{false}},
+		},
+		{
+			input:         "a + b",
+			expectedQuery: "(a + b)",
+			expectedExp: &InfixExpression{
+				Token:    Token{Type: PLUS, Literal: "+"},
+				Left:     &QualifiedName{Token: Token{Type: IDENT, Literal: "a"}, Parts: []string{"a"}, Quoted: []bool{false}},
+				Operator: "+",
+				Right:    &QualifiedName{Token: Token{Type: IDENT, Literal: "b"}, Parts: []string{"b"}, Quoted: []bool{false}},
 			},
 		},
 		{
 			input:         "now() as date",
 			expectedQuery: "now() AS date",
-			expectedExp: &SQLSource{
+			expectedExp: &SQLColumnExp{
 				Token: Token{Type: IDENT, Literal: "now"},
-				Value: "now()",
+				Value: &CallExpression{
+					Token:    Token{Type: LPAREN, Literal: "("},
+					Function: &Identifier{Token: Token{Type: IDENT, Literal: "now"}, Value: "now"},
+				},
 				Alias: "date",
 			},
 		},
 		{
 			input:         "db.table as t1",
 			expectedQuery: "db.table AS t1",
-			expectedExp: &SQLSource{
+			expectedExp: &SQLColumnExp{
 				Token: Token{Type: IDENT, Literal: "db"},
-				Value: "db.table",
+				Value: &QualifiedName{Token: Token{Type: IDENT, Literal: "db"}, Parts: []string{"db", "table"}, Quoted: []bool{false, false}},
 				Alias: "t1",
 			},
 		},
 		{
 			input:         "name as nm",
 			expectedQuery: "name AS nm",
-			expectedExp: &SQLSource{
+			expectedExp: &SQLColumnExp{
 				Token: Token{Type: IDENT, Literal: "name"},
-				Value: "name",
+				Value: &QualifiedName{Token: Token{Type: IDENT, Literal: "name"}, Parts: []string{"name"}, Quoted: []bool{false}},
 				Alias: "nm",
 			},
 		},
+		{
+			input:         "rollup(a, b)",
+			expectedQuery: "ROLLUP (a, b)",
+			expectedExp: &GroupingExp{
+				Token: Token{Type: IDENT, Literal: "rollup"},
+				Type:  SQLRollup,
+				Sets: [][]Expression{
+					{&QualifiedName{Token: Token{Type: IDENT, Literal: "a"}, Parts: []string{"a"}, Quoted: []bool{false}}},
+					{&QualifiedName{Token: Token{Type: IDENT, Literal: "b"}, Parts: []string{"b"}, Quoted: []bool{false}}},
+				},
+			},
+		},
+		{
+			input:         "grouping sets ((a, b), (a), ())",
+			expectedQuery: "GROUPING SETS ((a, b), a, ())",
+			expectedExp: &GroupingExp{
+				Token: Token{Type: IDENT, Literal: "grouping"},
+				Type:  SQLGroupingSets,
+				Sets: [][]Expression{
+					{
+						&QualifiedName{Token: Token{Type: IDENT, Literal: "a"}, Parts: []string{"a"}, Quoted: []bool{false}},
+						&QualifiedName{Token: Token{Type: IDENT, Literal: "b"}, Parts: []string{"b"}, Quoted: []bool{false}},
+					},
+					{&QualifiedName{Token: Token{Type: IDENT, Literal: "a"}, Parts: []string{"a"}, Quoted: []bool{false}}},
+					nil,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input))
+
+		exp := p.parseSQLGroup()
+		checkParserErrors(t, p)
+
+		require.Equal(t, tt.expectedQuery, exp.String())
+		require.EqualValuesf(t, tt.expectedExp, exp, "input: %s", tt.input)
+	}
+}
+
+func TestParser_parseSQLOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+		expectedExp   Expression
+	}{
+		{
+			input:         "toDate(ts) desc",
+			expectedQuery: "toDate(ts) DESC",
+			expectedExp: &SQLOrderExp{
+				Token: Token{Type: IDENT, Literal: "toDate"},
+				Value: &CallExpression{
+					Token:    Token{Type: LPAREN, Literal: "("},
+					Function: &Identifier{Token: Token{Type: IDENT, Literal: "toDate"}, Value: "toDate"},
+					Arguments: []Expression{
+						&QualifiedName{Token: Token{Type: IDENT, Literal: "ts"}, Parts: []string{"ts"}, Quoted: []bool{false}},
+					},
+				},
+				Direction: Token{Type: SQLDesc, Literal: "desc"},
+			},
+		},
+		{
+			input:         "name collate utf8_bin asc nulls last",
+			expectedQuery: "name COLLATE utf8_bin ASC NULLS LAST",
+			expectedExp: &SQLOrderExp{
+				Token:     Token{Type: IDENT, Literal: "name"},
+				Value:     &QualifiedName{Token: Token{Type: IDENT, Literal: "name"}, Parts: []string{"name"}, Quoted: []bool{false}},
+				Direction: Token{Type: SQLAsc, Literal: "asc"},
+				Nulls:     SQLLast,
+				Collate:   "utf8_bin",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input))
+
+		exp := p.parseSQLOrder()
+		checkParserErrors(t, p)
+
+		require.Equal(t, tt.expectedQuery, exp.String())
+		require.EqualValuesf(t, tt.expectedExp, exp, "input: %s", tt.input)
+	}
+}
+
+func TestParser_parseSQLTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+		expectedExp   Expression
+	}{
+		{
+			input:         "users",
+			expectedQuery: "users",
+			expectedExp: &SQLTableExp{
+				Token: Token{Type: IDENT, Literal: "users"},
+				Table: &QualifiedName{Token: Token{Type: IDENT, Literal: "users"}, Parts: []string{"users"}, Quoted: []bool{false}},
+			},
+		},
+		{
+			input:         "db.users as u",
+			expectedQuery: "db.users AS u",
+			expectedExp: &SQLTableExp{
+				Token: Token{Type: IDENT, Literal: "db"},
+				Table: &QualifiedName{Token: Token{Type: IDENT, Literal: "db"}, Parts: []string{"db", "users"}, Quoted: []bool{false, false}},
+				Alias: "u",
+			},
+		},
+		{
+			input:         "catalog.`public`.`order`",
+			expectedQuery: "catalog.public.order",
+			expectedExp: &SQLTableExp{
+				Token: Token{Type: IDENT, Literal: "catalog"},
+				Table: &QualifiedName{
+					Token:  Token{Type: IDENT, Literal: "catalog"},
+					Parts:  []string{"catalog", "public", "order"},
+					Quoted: []bool{false, true, true},
+				},
+			},
+		},
+		{
+			input:         "users u",
+			expectedQuery: "users AS u",
+			expectedExp: &SQLTableExp{
+				Token: Token{Type: IDENT, Literal: "users"},
+				Table: &QualifiedName{Token: Token{Type: IDENT, Literal: "users"}, Parts: []string{"users"}, Quoted: []bool{false}},
+				Alias: "u",
+			},
+		},
+		{
+			input:         "(select id from t) as sub(x)",
+			expectedQuery: "(SELECT id FROM t) AS sub(x)",
+			expectedExp: &SQLTableExp{
+				Token: Token{Type: LPAREN, Literal: "("},
+				Table: &SQLSubSelectExpression{
+					Token: Token{Type: LPAREN, Literal: "("},
+					Select: &SQLSelectStatement{
+						Token: Token{Type: SQLSelect, Literal: "select"},
+						SQLSelectColumns: []Expression{
+							&QualifiedName{Token: Token{Type: IDENT, Literal: "id"}, Parts: []string{"id"}, Quoted: []bool{false}},
+						},
+						From: []Expression{
+							&SQLTableExp{
+								Token: Token{Type: IDENT, Literal: "t"},
+								Table: &QualifiedName{Token: Token{Type: IDENT, Literal: "t"}, Parts: []string{"t"}, Quoted: []bool{false}},
+							},
+						},
+					},
+				},
+				Alias:   "sub",
+				Columns: []string{"x"},
+			},
+		},
+		{
+			input:         "numbers(10) as n",
+			expectedQuery: "numbers(10) AS n",
+			expectedExp: &SQLTableExp{
+				Token: Token{Type: IDENT, Literal: "numbers"},
+				Table: &TableFunction{
+					Token: Token{Type: IDENT, Literal: "numbers"},
+					Name:  "numbers",
+					Arguments: []Expression{
+						&IntegerLiteral{Token: Token{Type: INT, Literal: "10"}, Value: 10},
+					},
+				},
+				Alias: "n",
+			},
+		},
+		{
+			input:         "lateral unnest(t.tags) u(tag)",
+			expectedQuery: "LATERAL unnest(t.tags) AS u(tag)",
+			expectedExp: &SQLTableExp{
+				Token: Token{Type: IDENT, Literal: "lateral"},
+				Table: &TableFunction{
+					Token: Token{Type: IDENT, Literal: "unnest"},
+					Name:  "unnest",
+					Arguments: []Expression{
+						&QualifiedName{Token: Token{Type: IDENT, Literal: "t"}, Parts: []string{"t", "tags"}, Quoted: []bool{false, false}},
+					},
+				},
+				Alias:   "u",
+				Columns: []string{"tag"},
+				Lateral: true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		p := NewParser(NewLexer(tt.input))
 
-		exp := p.parseSQLSource()
+		exp := p.parseSQLTable()
 		checkParserErrors(t, p)
 
 		require.Equal(t, tt.expectedQuery, exp.String())
@@ -541,6 +829,80 @@ func TestParser_parseSQLSource(t *testing.T) {
 	}
 }
 
+func TestParser_parseSQLJoin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input       string
+		expectedExp *SQLJoinExp
+	}{
+		{
+			input: "global all left outer join t using (id)",
+			expectedExp: &SQLJoinExp{
+				Token:      Token{Type: SQLJoin},
+				Type:       SQLLeft,
+				Strictness: SQLAll,
+				Global:     true,
+				Table: &SQLTableExp{
+					Token: Token{Type: IDENT, Literal: "t"},
+					Table: &QualifiedName{Token: Token{Type: IDENT, Literal: "t"}, Parts: []string{"t"}, Quoted: []bool{false}},
+				},
+				Using: []string{"id"},
+			},
+		},
+		{
+			input: "cross join lateral (select 1)",
+			expectedExp: &SQLJoinExp{
+				Token: Token{Type: SQLJoin},
+				Type:  SQLCross,
+				Table: &SQLTableExp{
+					Token: Token{Type: IDENT, Literal: "lateral"},
+					Table: &SQLSubSelectExpression{
+						Token: Token{Type: LPAREN, Literal: "("},
+						Select: &SQLSelectStatement{
+							Token: Token{Type: SQLSelect, Literal: "select"},
+							SQLSelectColumns: []Expression{
+								&IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
+							},
+						},
+					},
+					Lateral: true,
+				},
+			},
+		},
+		{
+			input: "inner join remote('host', db, t) r using (id)",
+			expectedExp: &SQLJoinExp{
+				Token: Token{Type: SQLJoin},
+				Type:  SQLInner,
+				Table: &SQLTableExp{
+					Token: Token{Type: IDENT, Literal: "remote"},
+					Table: &TableFunction{
+						Token: Token{Type: IDENT, Literal: "remote"},
+						Name:  "remote",
+						Arguments: []Expression{
+							&StringLiteral{Token: Token{Type: STRING, Literal: "host"}, Value: "host"},
+							&QualifiedName{Token: Token{Type: IDENT, Literal: "db"}, Parts: []string{"db"}, Quoted: []bool{false}},
+							&QualifiedName{Token: Token{Type: IDENT, Literal: "t"}, Parts: []string{"t"}, Quoted: []bool{false}},
+						},
+					},
+					Alias: "r",
+				},
+				Using: []string{"id"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input))
+
+		exp := p.parseSQLJoin()
+		checkParserErrors(t, p)
+
+		require.EqualValuesf(t, tt.expectedExp, exp, "input: %s", tt.input)
+	}
+}
+
 func TestParser_parseSQLSelect(t *testing.T) {
 	t.Parallel()
 
@@ -618,6 +980,308 @@ func TestParser_parseSQLSelectStatementMulti(t *testing.T) {
 	}
 }
 
+func TestParser_parseSQLSelectStatementFunctionCasts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+	}{
+		{
+			input:         "select toInt64(x), toString(y), toDate(a, b) from t",
+			expectedQuery: "SELECT toInt64(x), toString(y), toDate(a, b) FROM t;",
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input), WithFunctionCasts())
+
+		stmt := p.parseSQLSelectStatement()
+		checkParserErrors(t, p)
+
+		if !testSelectStatement(t, stmt, tt.expectedQuery) {
+			return
+		}
+
+		require.IsType(t, &CastExpression{}, stmt.SQLSelectColumns[0])
+		require.Equal(t, "CAST(x AS Int64)", structcher(stmt.SQLSelectColumns[0]))
+		require.IsType(t, &CallExpression{}, stmt.SQLSelectColumns[2])
+	}
+}
+
+func TestParser_parseSQLSelectStatementPostgreSQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+	}{
+		{
+			input:         "select distinct on (a, b) a, c from t order by a",
+			expectedQuery: "SELECT DISTINCT ON (a, b) a, c FROM t ORDER BY a;",
+		},
+		{
+			input:         `select "Id"::text from "public"."Users" where data @> '{"a": 1}' and tags <@ ARRAY['x', 'y']`,
+			expectedQuery: `SELECT Id::text FROM public.Users WHERE ((data @> {"a": 1}) AND (tags <@ [x, y]));`,
+		},
+		{
+			input: "select * from t where j->'a'->>'b' = ? and j #>> '{a,b}' = $1 and j ? 'k' and j ?| array['a'] and j ?& $tag$b$tag$",
+			expectedQuery: "SELECT * FROM t WHERE (((((((j -> a) ->> b) = ?) AND ((j #>> {a,b}) = $1)) AND (j ? k)) AND " +
+				"(j ?| [a])) AND (j ?& b));",
+		},
+		{
+			input:         "select $$it's$$ as s",
+			expectedQuery: "SELECT it's AS s;",
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input), WithDialect(DialectPostgreSQL))
+
+		stmt := p.parseSQLSelectStatement()
+		checkParserErrors(t, p)
+
+		if !testSelectStatement(t, stmt, tt.expectedQuery) {
+			return
+		}
+	}
+}
+
+func TestParser_parseSQLSelectStatementMySQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+	}{
+		{
+			input:         "select sql_calc_found_rows `id`, `order` from `db`.`users` u use index (idx_a, idx_b) limit 10",
+			expectedQuery: "SELECT SQL_CALC_FOUND_ROWS id, order FROM db.users AS u USE INDEX (idx_a, idx_b) LIMIT 10;",
+		},
+		{
+			input: "select distinct sql_no_cache * from t force key for order by (i) ignore index for join () " +
+				"join s ignore index (j) on s.id = t.id",
+			expectedQuery: "SELECT DISTINCT SQL_NO_CACHE * FROM t FORCE INDEX FOR ORDER BY (i) IGNORE INDEX FOR JOIN () " +
+				"JOIN s IGNORE INDEX (j) ON (s.id = t.id);",
+		},
+		{
+			input:         "select * from t where id = 1 for update",
+			expectedQuery: "SELECT * FROM t WHERE (id = 1) FOR UPDATE;",
+		},
+		{
+			input:         "select * from t order by id limit 1 for share of t, s skip locked",
+			expectedQuery: "SELECT * FROM t ORDER BY id LIMIT 1 FOR SHARE OF t, s SKIP LOCKED;",
+		},
+		{
+			input:         "select * from t where id in (1, 2) lock in share mode",
+			expectedQuery: "SELECT * FROM t WHERE id IN (1, 2) LOCK IN SHARE MODE;",
+		},
+		{
+			input:         "select data->'$.a', data->>'$.b' from t where data->>'$.id' = 1",
+			expectedQuery: "SELECT (data -> $.a), (data ->> $.b) FROM t WHERE ((data ->> $.id) = 1);",
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input), WithDialect(DialectMySQL))
+
+		stmt := p.parseSQLSelectStatement()
+		checkParserErrors(t, p)
+
+		if !testSelectStatement(t, stmt, tt.expectedQuery) {
+			return
+		}
+	}
+}
+
+func TestParser_parseSQLSelectStatementPrecedence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		dialect       Dialect
+		expectedQuery string
+	}{
+		{
+			input:         "select * from t where a = 1 or b = 2 and c = 3",
+			expectedQuery: "SELECT * FROM t WHERE ((a = 1) OR ((b = 2) AND (c = 3)));",
+		},
+		{
+			input:         "select * from t where not a = 1 and b <> 2",
+			expectedQuery: "SELECT * FROM t WHERE ((NOT (a = 1)) AND (b != 2));",
+		},
+		{
+			input:         "select * from t where a <= 1 or b is not null and c like 'x%'",
+			expectedQuery: "SELECT * FROM t WHERE ((a <= 1) OR ((b IS NOT null) AND (c LIKE x%)));",
+		},
+		{
+			input:         "select a - b - c, a + b * c % d, a | b + c, a << 1 & b, 2 ^ 3 * 4, ~a from t",
+			expectedQuery: "SELECT ((a - b) - c), (a + ((b * c) % d)), (a | (b + c)), ((a << 1) & b), ((2 ^ 3) * 4), (~a) FROM t;",
+		},
+		{
+			input:         "select a || b = c, t.a[1]::int from t where x between 1 + 1 and 3 and y",
+			expectedQuery: "SELECT ((a || b) = c), (t.a[1])::int FROM t WHERE ((x BETWEEN (1 + 1) AND 3) AND y);",
+		},
+		{
+			input:         "select * from t where a = 1 || b = 2 and c <=> 3",
+			dialect:       DialectMySQL,
+			expectedQuery: "SELECT * FROM t WHERE ((a = 1) OR ((b = 2) AND (c <=> 3)));",
+		},
+		{
+			input:         "select a | b & c, a & b << 1, a << 1 | b, a | b << c & d from t",
+			dialect:       DialectMySQL,
+			expectedQuery: "SELECT (a | (b & c)), (a & (b << 1)), ((a << 1) | b), (a | ((b << c) & d)) FROM t;",
+		},
+		{
+			input:         "select a << 1 + 2, 2 ^ 3 * 4, a * b ^ c, -a ^ b from t",
+			dialect:       DialectMySQL,
+			expectedQuery: "SELECT (a << (1 + 2)), ((2 ^ 3) * 4), (a * (b ^ c)), ((-a) ^ b) FROM t;",
+		},
+		{
+			input:         "select a | b & c, a & b << 1 from t",
+			expectedQuery: "SELECT ((a | b) & c), ((a & b) << 1) FROM t;",
+		},
+		{
+			input:         "select * from t where a like 'x' = false",
+			dialect:       DialectMySQL,
+			expectedQuery: "SELECT * FROM t WHERE ((a LIKE x) = false);",
+		},
+		{
+			input:         "select * from t where a = b between 1 and 2 and c = d not between 1 and 2",
+			dialect:       DialectMySQL,
+			expectedQuery: "SELECT * FROM t WHERE (((a = b) BETWEEN 1 AND 2) AND ((c = d) NOT BETWEEN 1 AND 2));",
+		},
+		{
+			input:         "select * from t where a = b between 1 and 2 and c = d not between 1 and 2",
+			expectedQuery: "SELECT * FROM t WHERE ((a = (b BETWEEN 1 AND 2)) AND (c = (d NOT BETWEEN 1 AND 2)));",
+		},
+		{
+			input:         "select * from t where a = b like 'x'",
+			dialect:       DialectPostgreSQL,
+			expectedQuery: "SELECT * FROM t WHERE (a = (b LIKE x));",
+		},
+		{
+			input:         "select (a + 1 as x) * 2 as y, cast(b as int) from t where (id as i) = 1 or i > 2",
+			expectedQuery: "SELECT (((a + 1) AS x) * 2) AS y, CAST(b AS int) FROM t WHERE (((id AS i) = 1) OR (i > 2));",
+		},
+		{
+			input:         "select sum(a or b as c) from t group by c as d",
+			expectedQuery: "SELECT sum(((a OR b) AS c)) FROM t GROUP BY c AS d;",
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input, WithDialect(tt.dialect)), WithDialect(tt.dialect))
+
+		stmt := p.parseSQLSelectStatement()
+		checkParserErrors(t, p)
+
+		if !testSelectStatement(t, stmt, tt.expectedQuery) {
+			return
+		}
+	}
+}
+
+func TestParser_parseSQLInsertStatement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+	}{
+		{
+			input:         "insert into db.users (id, name) values (1, 'a'), (2, 'b')",
+			expectedQuery: "INSERT INTO db.users (id, name) VALUES (1, a), (2, b);",
+		},
+		{
+			input:         "insert into t default values returning id",
+			expectedQuery: "INSERT INTO t DEFAULT VALUES RETURNING id;",
+		},
+		{
+			input:         "insert into t (a) select a from s where b > 1 returning *",
+			expectedQuery: "INSERT INTO t (a) SELECT a FROM s WHERE (b > 1) RETURNING *;",
+		},
+		{
+			input:         "insert into t select 1 on conflict on constraint t_pkey do nothing",
+			expectedQuery: "INSERT INTO t SELECT 1 ON CONFLICT ON CONSTRAINT t_pkey DO NOTHING;",
+		},
+		{
+			input: "INSERT INTO users AS u (id, name) VALUES ($1, $2) " +
+				"ON CONFLICT (id) DO UPDATE SET name = excluded.name, hits = u.hits + 1 WHERE u.active " +
+				"RETURNING id, name AS n",
+			expectedQuery: "INSERT INTO users AS u (id, name) VALUES ($1, $2) " +
+				"ON CONFLICT (id) DO UPDATE SET name = excluded.name, hits = (u.hits + 1) WHERE u.active " +
+				"RETURNING id, name AS n;",
+		},
+		{
+			input:         "replace into t (a, b) values (1, 2)",
+			expectedQuery: "REPLACE INTO t (a, b) VALUES (1, 2);",
+		},
+		{
+			input: "insert ignore into t (a, hits) values (1, 1) " +
+				"on duplicate key update hits = hits + 1, a = values(a)",
+			expectedQuery: "INSERT IGNORE INTO t (a, hits) VALUES (1, 1) " +
+				"ON DUPLICATE KEY UPDATE hits = (hits + 1), a = values(a);",
+		},
+		{
+			input:         "insert into t (a) select a from s on duplicate key update a = s.a",
+			expectedQuery: "INSERT INTO t (a) SELECT a FROM s ON DUPLICATE KEY UPDATE a = s.a;",
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input))
+
+		stmt := p.parseSQLInsertStatement()
+		checkParserErrors(t, p)
+
+		require.Equal(t, tt.expectedQuery, stmt.String())
+	}
+
+	p := NewParser(NewLexer("insert into t (a, b) values (1, ?) on conflict do nothing"))
+
+	stmt := p.parseSQLInsertStatement()
+	checkParserErrors(t, p)
+
+	require.EqualValues(t, &SQLInsertStatement{
+		Token:   Token{Type: IDENT, Literal: "insert"},
+		Table:   &QualifiedName{Token: Token{Type: IDENT, Literal: "t"}, Parts: []string{"t"}, Quoted: []bool{false}},
+		Columns: []string{"a", "b"},
+		Values: [][]Expression{{
+			&IntegerLiteral{Token: Token{Type: INT, Literal: "1"}, Value: 1},
+			&Placeholder{Token: Token{Type: PLACEHOLDER, Literal: "?"}},
+		}},
+		OnConflict: &OnConflictExp{Token: Token{Type: SQLOn, Literal: "on"}},
+	}, stmt)
+}
+
+func TestParser_parseSQLInsertStatementError(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"insert t values (1)",
+		"insert into t (a) values",
+		"insert into t values ()",
+		"insert into t (a) set a = 1",
+		"insert into t values (1) on conflict do update a = 1",
+		"insert into t values (1) returning id id2 id3",
+		"select 1 returning id",
+		"insert into t values (1) on duplicate update a = 1",
+		"replace t values (1)",
+		"select * from t use (i)",
+		"select * from t use index for (i)",
+		"select * from t for update of",
+		"select * from t lock in share",
+	}
+
+	for _, input := range tests {
+		p := NewParser(NewLexer(input))
+
+		p.parseSQLStatement()
+		require.NotEmptyf(t, p.Errors(), "input: %s", input)
+	}
+}
+
 func TestParser_parseSQLSelectStatementError(t *testing.T) {
 	t.Parallel()
 
@@ -630,19 +1294,126 @@ func TestParser_parseSQLSelectStatementError(t *testing.T) {
 		{
 			input: "select x as 3 fr om 1",
 		},
+		{
+			input: "select a from t group by a b",
+		},
+		{
+			input: "select a b c from t",
+		},
+		{
+			input: "select * from t x y",
+		},
+		{
+			input: "select * from a join b on where x = 1",
+		},
+		{
+			input: "select * from a join b on",
+		},
+		{
+			input: "select * from a join b on x = 1 and",
+		},
+		{
+			input: "select * from t limit 10 limit 20",
+		},
+		{
+			input: "select * from t offset 1 offset 2",
+		},
+		{
+			input: "select * from t limit 10 offset 1 offset 2",
+		},
+		{
+			input: "select * from t limit 10 fetch first 5 rows only",
+		},
+		{
+			input: "select * from t limit ,",
+		},
+		{
+			input: "select * from t limit 1 by a limit 2 by b",
+		},
 	}
 
 	for _, tt := range tests {
 		p := NewParser(NewLexer(tt.input))
 
 		stmt := p.parseSQLSelectStatement()
-		require.Truef(t, len(p.Errors()) != 0, "input: %s, %s", tt.input, stmt.String())
-		_ = stmt
-		t.Log(stmt.String())
+		require.Truef(t, len(p.Errors()) != 0, "input: %s", tt.input)
+		if stmt != nil {
+			t.Log(stmt.String())
+		}
 		t.Log(p.errors)
 	}
 }
 
+func TestParser_parseSQLSelectStatementRecovery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+		bad           map[TokenType]string
+	}{
+		{
+			input:         "select a, b c d from t where x = 1 group by a",
+			expectedQuery: "SELECT a, b AS c FROM t WHERE (x = 1) GROUP BY a;",
+			bad:           map[TokenType]string{SQLSelect: "d"},
+		},
+		{
+			input:         "select a from t group x, y order by b limit 1",
+			expectedQuery: "SELECT a FROM t ORDER BY b LIMIT 1;",
+			bad:           map[TokenType]string{SQLGroup: "group x , y"},
+		},
+		{
+			input:         "select a from t join where x = 1 order by",
+			expectedQuery: "SELECT a FROM t WHERE (x = 1);",
+			bad:           map[TokenType]string{SQLJoin: "", SQLOrder: ""},
+		},
+		{
+			input:         "select a from (select b from s group c) as q where x = 1",
+			expectedQuery: "SELECT a FROM (SELECT b FROM s) AS q WHERE (x = 1);",
+			bad:           map[TokenType]string{SQLFrom: ""},
+		},
+		{
+			input:         "select a from t order by a limit 1 where x = 1",
+			expectedQuery: "SELECT a FROM t ORDER BY a LIMIT 1;",
+			bad:           map[TokenType]string{SQLWhere: "where x = 1"},
+		},
+		{
+			input:         "select a, from t",
+			expectedQuery: "SELECT a FROM t;",
+			bad:           map[TokenType]string{SQLSelect: ""},
+		},
+		{
+			input:         "select a from (select b from t where x = ) s where y = 1",
+			expectedQuery: "SELECT a FROM (SELECT b FROM t) AS s WHERE (y = 1);",
+			bad:           map[TokenType]string{SQLFrom: ""},
+		},
+		{
+			input:         "select a from t where a = = 1 group by b) order by c",
+			expectedQuery: "SELECT a FROM t GROUP BY b;",
+			bad:           map[TokenType]string{SQLWhere: "= 1", ILLEGAL: ")", SQLOrder: "order by c"},
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewParser(NewLexer(tt.input), WithRecovery())
+
+		stmt := p.parseSQLSelectStatement()
+		require.NotEmptyf(t, p.Errors(), "input: %s", tt.input)
+		require.NotNilf(t, stmt, "input: %s", tt.input)
+		require.Equal(t, tt.expectedQuery, stmt.String())
+
+		bad := make(map[TokenType]string, len(stmt.Bad))
+		for _, exp := range stmt.Bad {
+			require.NotEmpty(t, exp.Errors)
+			bad[exp.Clause] = exp.String()
+		}
+		require.Equal(t, tt.bad, bad)
+
+		p = NewParser(NewLexer(tt.input))
+		require.Nilf(t, p.parseSQLSelectStatement(), "input: %s", tt.input)
+	}
+}
+
 func TestParser_parseSQLSelectStatementWithJoin(t *testing.T) {
 	t.Parallel()
 
@@ -678,6 +1449,28 @@ func TestParser_parseSQLSelectStatementWithJoin(t *testing.T) {
 				"LEFT JOIN user AS a ON (s.user_id = a.id) LEFT JOIN user_info AS i ON (i.user_id = u.id) " +
 				"WHERE ((u.status != deleted) AND a.id IN (998));",
 		},
+		{
+			input:         "select * from a full outer join b on a.id = b.id natural join c left join d using (id, name) cross join e",
+			expectedQuery: "SELECT * FROM a FULL JOIN b ON (a.id = b.id) NATURAL JOIN c LEFT JOIN d USING (id, name) CROSS JOIN e;",
+		},
+		{
+			input: "select * from a global any left join b using id asof join c on a.t >= c.t " +
+				"left semi join d on d.x = a.x all inner join f on f.a = a.a",
+			expectedQuery: "SELECT * FROM a GLOBAL ANY LEFT JOIN b USING (id) ASOF JOIN c ON (a.t >= c.t) " +
+				"SEMI LEFT JOIN d ON (d.x = a.x) ALL INNER JOIN f ON (f.a = a.a);",
+		},
+		{
+			input:         "select * from a join b on a.id = b.id, c, lateral (select * from d where d.a = c.a) as x where a.z = 1",
+			expectedQuery: "SELECT * FROM a JOIN b ON (a.id = b.id), c, LATERAL (SELECT * FROM d WHERE (d.a = c.a)) AS x WHERE (a.z = 1);",
+		},
+		{
+			input:         "select * from a, b join c on c.x = b.x, d left join e using (id)",
+			expectedQuery: "SELECT * FROM a, b JOIN c ON (c.x = b.x), d LEFT JOIN e USING (id);",
+		},
+		{
+			input:         "select full, semi from t as any full join x on x.a = t.a",
+			expectedQuery: "SELECT full, semi FROM t AS any FULL JOIN x ON (x.a = t.a);",
+		},
 	}
 
 	for _, tt := range tests {
@@ -718,14 +1511,154 @@ func TestParser_parseSQLSelectStatement(t *testing.T) {
 		},
 		{input: "select * from t WHERE id = 1 LIMIT 10", expectedQuery: "SELECT * FROM t WHERE (id = 1) LIMIT 10;"},
 		{input: "select * from t WHERE id = 1 LIMIT 5, 10", expectedQuery: "SELECT * FROM t WHERE (id = 1) LIMIT 5, 10;"},
+		{input: "select * from t WHERE id = 1 LIMIT 10 OFFSET 5", expectedQuery: "SELECT * FROM t WHERE (id = 1) LIMIT 10 OFFSET 5;"},
+		{input: "select * from t LIMIT ALL OFFSET 5", expectedQuery: "SELECT * FROM t LIMIT ALL OFFSET 5;"},
+		{input: "select * from t OFFSET 5 LIMIT 10", expectedQuery: "SELECT * FROM t LIMIT 10 OFFSET 5;"},
+		{input: "select 1e10, 1.5e10 x, 2E-3 from t", expectedQuery: "SELECT 1e10, 1.5e10 AS x, 2E-3 FROM t;"},
+		{input: "select * from t LIMIT ?, ?", expectedQuery: "SELECT * FROM t LIMIT ?, ?;"},
+		{input: "select * from t LIMIT $1 OFFSET $2", expectedQuery: "SELECT * FROM t LIMIT $1 OFFSET $2;"},
+		{input: "select * from t LIMIT :size * 2", expectedQuery: "SELECT * FROM t LIMIT (:size * 2);"},
+		{
+			input:         "select * from t order by id offset 20 rows fetch first 10 rows only",
+			expectedQuery: "SELECT * FROM t ORDER BY id OFFSET 20 ROWS FETCH FIRST 10 ROWS ONLY;",
+		},
+		{input: "select * from t fetch next 1 row only", expectedQuery: "SELECT * FROM t FETCH FIRST 1 ROWS ONLY;"},
+		{input: "select a from t group by now() as date", expectedQuery: "SELECT a FROM t GROUP BY now() AS date;"},
+		{input: "select offset, fetch, all from t offset 1", expectedQuery: "SELECT offset, fetch, all FROM t OFFSET 1;"},
+		{input: "select * from t where id > all (select id from s)", expectedQuery: "SELECT * FROM t WHERE (id > ALL (SELECT id FROM s));"},
 		{
 			input:         "select * from t where date between '2020-01-01' AND '2023-10-10' AND id > 3",
-			expectedQuery: "SELECT * FROM t WHERE (date BETWEEN 2020-01-01 AND 2023-10-10 AND (id > 3));",
+			expectedQuery: "SELECT * FROM t WHERE ((date BETWEEN 2020-01-01 AND 2023-10-10) AND (id > 3));",
 		},
 		{
 			input:         "select (select max(price) from orders) as max_price, name from users",
 			expectedQuery: "SELECT (SELECT max(price) FROM orders) AS max_price, name FROM users;",
 		},
+		{
+			input: "select t.id from (select id, count(*) from orders group by id order by id limit 1) as t " +
+				"left join (select user_id from users where age > 18) as u on u.user_id = t.id",
+			expectedQuery: "SELECT t.id FROM (SELECT id, count(*) FROM orders GROUP BY id ORDER BY id LIMIT 1) AS t " +
+				"LEFT JOIN (SELECT user_id FROM users WHERE (age > 18)) AS u ON (u.user_id = t.id);",
+		},
+		{
+			input:         "select * from users as u where exists (select 1 from orders as o where o.user_id = u.id)",
+			expectedQuery: "SELECT * FROM users AS u WHERE EXISTS (SELECT 1 FROM orders AS o WHERE (o.user_id = u.id));",
+		},
+		{
+			input:         "select * from users where not exists (select 1 from bans where bans.id = users.id) and id = 5",
+			expectedQuery: "SELECT * FROM users WHERE (NOT EXISTS (SELECT 1 FROM bans WHERE (bans.id = users.id)) AND (id = 5));",
+		},
+		{
+			input:         "select exists from t where exists = 1 and not exists and exists (select 1)",
+			expectedQuery: "SELECT exists FROM t WHERE (((exists = 1) AND (NOT exists)) AND EXISTS (SELECT 1));",
+		},
+		{
+			input:         "select * from users where id in (select user_id from orders) and id not in (select id from bans)",
+			expectedQuery: "SELECT * FROM users WHERE (id IN (SELECT user_id FROM orders) AND id NOT IN (SELECT id FROM bans));",
+		},
+		{
+			input:         "select * from t where price > any (select price from p) or price < all (select price from p)",
+			expectedQuery: "SELECT * FROM t WHERE ((price > ANY (SELECT price FROM p)) OR (price < ALL (SELECT price FROM p)));",
+		},
+		{
+			input:         "select any(x) from t where age = (select max(age) from users)",
+			expectedQuery: "SELECT any(x) FROM t WHERE (age = (SELECT max(age) FROM users));",
+		},
+		{
+			input:         "select user_id, row_number() over (partition by user_id order by ts desc) as rn from events",
+			expectedQuery: "SELECT user_id, row_number() OVER (PARTITION BY user_id ORDER BY ts DESC) AS rn FROM events;",
+		},
+		{
+			input: "select sum(amount) over (partition by a, b order by ts rows between unbounded preceding and current row) from t",
+			expectedQuery: "SELECT sum(amount) OVER (PARTITION BY a, b ORDER BY ts ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) " +
+				"FROM t;",
+		},
+		{
+			input:         "select avg(v) over (order by ts range between 3 preceding and 2 following), count(*) over () from t",
+			expectedQuery: "SELECT avg(v) OVER (ORDER BY ts RANGE BETWEEN 3 PRECEDING AND 2 FOLLOWING), count(*) OVER () FROM t;",
+		},
+		{
+			input: "select sum(x) over w, max(x) over (w rows 1 preceding) from t group by a " +
+				"window w as (partition by a order by b), w2 as (w) order by a",
+			expectedQuery: "SELECT sum(x) OVER w, max(x) OVER (w ROWS 1 PRECEDING) FROM t GROUP BY a " +
+				"WINDOW w AS (PARTITION BY a ORDER BY b), w2 AS (w) ORDER BY a;",
+		},
+		{
+			input:         "select window, over from t where window > over window w as (order by over)",
+			expectedQuery: "SELECT window, over FROM t WHERE (window > over) WINDOW w AS (ORDER BY over);",
+		},
+		{
+			input:         "select cast(x as Nullable(String)), cast(y as double precision), cast(z, 'String') from t",
+			expectedQuery: "SELECT CAST(x AS Nullable(String)), CAST(y AS double precision), CAST(z AS String) FROM t;",
+		},
+		{
+			input:         "select x::int, y::varchar(10)[], convert(a, char), convert(b using utf8mb4) from t",
+			expectedQuery: "SELECT x::int, y::varchar(10)[], CONVERT(a, char), CONVERT(b USING utf8mb4) FROM t;",
+		},
+		{
+			input: "select extract(year from ts) from t " +
+				"where d > date '2020-01-01' and ts < timestamp '2020-01-01 10:00:00' + interval 2 hours",
+			expectedQuery: "SELECT EXTRACT(YEAR FROM ts) FROM t " +
+				"WHERE ((d > DATE 2020-01-01) AND (ts < (TIMESTAMP 2020-01-01 10:00:00 + INTERVAL 2 HOUR)));",
+		},
+		{
+			input:         "select a, b, count(*) from t group by a, b with rollup order by toDate(ts) desc nulls first, 2",
+			expectedQuery: "SELECT a, b, count(*) FROM t GROUP BY a, b WITH ROLLUP ORDER BY toDate(ts) DESC NULLS FIRST, 2;",
+		},
+		{
+			input:         "select a from t group by cube(a, b), grouping sets ((a), ()) order by a + 1 limit 5",
+			expectedQuery: "SELECT a FROM t GROUP BY CUBE (a, b), GROUPING SETS (a, ()) ORDER BY (a + 1) LIMIT 5;",
+		},
+		{
+			input:         "select distinct count(*) cnt, u.name `n` from users u join (select 1) s(x) on s.x = u.id",
+			expectedQuery: "SELECT DISTINCT count(*) AS cnt, u.name AS n FROM users AS u JOIN (SELECT 1) AS s(x) ON (s.x = u.id);",
+		},
+		{
+			input: "select x key, x mode, a first, b last, c value, d type, e rows, f range, g current " +
+				"from t data join u status using (id) where data.k = status.k",
+			expectedQuery: "SELECT x AS key, x AS mode, a AS first, b AS last, c AS value, d AS type, e AS rows, f AS range, " +
+				"g AS current FROM t AS data JOIN u AS status USING (id) WHERE (data.k = status.k);",
+		},
+		{
+			input:         "select a from t final sample 0.1 array join arr full join u using (id) limit 1 offset 2",
+			expectedQuery: "SELECT a FROM t FINAL SAMPLE 0.1 ARRAY JOIN arr FULL JOIN u USING (id) LIMIT 1 OFFSET 2;",
+		},
+		{
+			input:         "select (1, 2) as t from t where (a, b) not in (select a, b from t2) and (x, y) = (1, 'a')",
+			expectedQuery: "SELECT (1, 2) AS t FROM t WHERE ((a, b) NOT IN (SELECT a, b FROM t2) AND ((x, y) = (1, a)));",
+		},
+		{
+			input: "select a, b from t final sample 1/10 offset 0.5 left array join arr as a, arr2 b join x on x.id = t.id " +
+				"prewhere d > 1 where c = 2 group by a with totals order by a limit 2 by a, b limit 10 " +
+				"settings max_threads = 8, use_uncompressed_cache = 0 format JSONEachRow",
+			expectedQuery: "SELECT a, b FROM t FINAL SAMPLE (1 / 10) OFFSET 0.5 LEFT ARRAY JOIN arr AS a, arr2 AS b JOIN x ON (x.id = t.id) " +
+				"PREWHERE (d > 1) WHERE (c = 2) GROUP BY a WITH TOTALS ORDER BY a LIMIT 2 BY a, b LIMIT 10 " +
+				"SETTINGS max_threads = 8, use_uncompressed_cache = 0 FORMAT JSONEachRow;",
+		},
+		{
+			input:         "select * from t sample 0.1 array join x limit 1 offset 2 by a limit 5 offset 2",
+			expectedQuery: "SELECT * FROM t SAMPLE 0.1 ARRAY JOIN x LIMIT 1 OFFSET 2 BY a LIMIT 5 OFFSET 2;",
+		},
+		{
+			input:         "select * from t where id not in (1,2) and name not like 'a%'",
+			expectedQuery: "SELECT * FROM t WHERE (id NOT IN (1, 2) AND (name NOT LIKE a%));",
+		},
+		{
+			input:         "select * from t where id not between 1 and 3 or name ilike 'x'",
+			expectedQuery: "SELECT * FROM t WHERE ((id NOT BETWEEN 1 AND 3) OR (name ILIKE x));",
+		},
+		{
+			input:         "select * from t where a similar to 'x' and b not similar to 'y' and c regexp 'z' and d not rlike 'w'",
+			expectedQuery: "SELECT * FROM t WHERE ((((a SIMILAR TO x) AND (b NOT SIMILAR TO y)) AND (c REGEXP z)) AND (d NOT RLIKE w));",
+		},
+		{
+			input:         "select similar, regexp from t where regexp = 1 and ilike rlike 'x' and rlike not ilike similar",
+			expectedQuery: "SELECT similar, regexp FROM t WHERE (((regexp = 1) AND (ilike RLIKE x)) AND (rlike NOT ILIKE similar));",
+		},
+		{
+			input:         "select * from t where x is null and y is not null and not z = 1",
+			expectedQuery: "SELECT * FROM t WHERE (((x IS null) AND (y IS NOT null)) AND (NOT (z = 1)));",
+		},
 		{
 			input: "SELECT " +
 				"`date` AS `time`, sum(req) AS `req_total`, sum(req2) AS `req2_total`,  sum(res) AS `res_total`, sum(res2) AS `res2_total` " +
@@ -735,9 +1668,13 @@ func TestParser_parseSQLSelectStatement(t *testing.T) {
 			expectedQuery: "SELECT " +
 				"date AS time, sum(req) AS req_total, sum(req2) AS req2_total, sum(res) AS res_total, sum(res2) AS res2_total " +
 				"FROM a_requests " +
-				"WHERE (date BETWEEN 2016-11-01 AND 2016-11-30 AND timestamp BETWEEN 2016-11-01 00:00:00 AND 2016-11-30 23:59:59) " +
+				"WHERE ((date BETWEEN 2016-11-01 AND 2016-11-30) AND (timestamp BETWEEN 2016-11-01 00:00:00 AND 2016-11-30 23:59:59)) " +
 				"GROUP BY time;",
 		},
+		{
+			input:         "select arrayFilter((x, y) -> x > y, a, b), arr[1].2, [1, 2] from t where has(m['k'], 1)",
+			expectedQuery: "SELECT arrayFilter((x, y) -> (x > y), a, b), (arr[1]).2, [1, 2] FROM t WHERE has((m[k]), 1);",
+		},
 	}
 
 	for _, tt := range tests {
@@ -799,7 +1736,7 @@ func testLiteralExpression(
 	case bool:
 		return testBooleanLiteral(t, exp, v)
 	case string:
-		return testIdentifier(t, exp, v)
+		return testName(t, exp, v)
 	}
 
 	t.Errorf("type of exp not handled. got=%T", exp)
@@ -807,19 +1744,19 @@ func testLiteralExpression(
 	return false
 }
 
-func testIdentifier(t *testing.T, exp Expression, value string) bool {
-	ident, ok := exp.(*Identifier)
+func testName(t *testing.T, exp Expression, value string) bool {
+	name, ok := exp.(*QualifiedName)
 	if !ok {
-		t.Errorf("exp not *ast.Identifier. got=%T", exp)
+		t.Errorf("exp not *ast.QualifiedName. got=%T", exp)
 		return false
 	}
-	if ident.Value != value {
-		t.Errorf("ident.Value not %s. got=%s", value, ident.Value)
+	if name.String() != value {
+		t.Errorf("name not %s. got=%s", value, name.String())
 		return false
 	}
-	if ident.TokenLiteral() != value {
-		t.Errorf("ident.TokenLiteral not %s. got=%s", value,
-			ident.TokenLiteral())
+	if name.TokenLiteral() != value {
+		t.Errorf("name.TokenLiteral not %s. got=%s", value,
+			name.TokenLiteral())
 		return false
 	}
 
@@ -0,0 +1,247 @@
+package sqlcmp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseSpecialCall parse functions with non-standard arguments like: CAST(x AS type), EXTRACT(YEAR FROM ts).
+// It returns false when the function is an ordinary one.
+func (p *Parser) parseSpecialCall(ident *Identifier) (Expression, bool) {
+	switch LookupSoftKeyword(ident.Value) {
+	case SQLCast:
+		return p.parseCastExpression(ident.Token), true
+	case SQLConvert:
+		return p.parseConvertExpression(ident.Token), true
+	case SQLExtract:
+		return p.parseExtractExpression(ident), true
+	}
+
+	if tp, ok := castFunctions[strings.ToLower(ident.Value)]; ok && p.config.functionCasts {
+		return p.parseCastFunction(ident, tp), true
+	}
+
+	return nil, false
+}
+
+// parseCastExpression parse CAST(x AS type) and ClickHouse CAST(x, 'type').
+func (p *Parser) parseCastExpression(tok Token) Expression {
+	exp := &CastExpression{Token: tok, Syntax: CastFunction}
+	p.nextToken() // skip (
+
+	if exp.Value = p.parseExpression(ALIAS); exp.Value == nil {
+		return nil
+	}
+
+	switch {
+	case p.peekTokenIs(SQLAs):
+		p.nextToken()
+	case p.peekTokenIs(COMMA):
+		p.nextToken()
+
+		if !p.expectPeek(STRING) {
+			return nil
+		}
+		exp.Type = p.curToken.Literal
+
+		if !p.expectPeek(RPAREN) {
+			return nil
+		}
+
+		return exp
+	default:
+		p.peekError(SQLAs)
+		return nil
+	}
+
+	p.nextToken()
+	if exp.Type = p.parseTypeName(true); exp.Type == "" {
+		return nil
+	}
+
+	if !p.expectPeek(RPAREN) {
+		return nil
+	}
+
+	return exp
+}
+
+// parseConvertExpression parse CONVERT(x, type) and CONVERT(x USING charset).
+func (p *Parser) parseConvertExpression(tok Token) Expression {
+	exp := &CastExpression{Token: tok, Syntax: CastConvert}
+	p.nextToken() // skip (
+
+	if exp.Value = p.parseExpression(LOWEST); exp.Value == nil {
+		return nil
+	}
+
+	switch {
+	case p.peekSoftKeywordIs(SQLUsing):
+		exp.Syntax = CastConvertUsing
+	case p.peekTokenIs(COMMA):
+	default:
+		p.peekError(COMMA)
+		return nil
+	}
+	p.nextToken()
+	p.nextToken()
+
+	if exp.Type = p.parseTypeName(true); exp.Type == "" {
+		return nil
+	}
+
+	if !p.expectPeek(RPAREN) {
+		return nil
+	}
+
+	return exp
+}
+
+// parseExtractExpression parse EXTRACT(field FROM x), ClickHouse extract(haystack, pattern) is ordinary function.
+func (p *Parser) parseExtractExpression(ident *Identifier) Expression {
+	call := &CallExpression{Token: p.curToken, Function: ident}
+	if p.peekTokenIs(RPAREN) {
+		p.nextToken()
+		return call
+	}
+	p.nextToken()
+
+	first := p.parseExpression(LOWEST)
+	if first == nil {
+		return nil
+	}
+
+	if !p.peekTokenIs(SQLFrom) {
+		if call.Arguments = p.parseExpressionListFrom(first, RPAREN); call.Arguments == nil {
+			return nil
+		}
+
+		return call
+	}
+
+	exp := &ExtractExpression{Token: ident.Token, Field: strings.ToUpper(first.String())}
+	p.nextToken()
+	p.nextToken()
+
+	if exp.From = p.parseExpression(LOWEST); exp.From == nil {
+		return nil
+	}
+
+	if !p.expectPeek(RPAREN) {
+		return nil
+	}
+
+	return exp
+}
+
+// parseCastFunction parse ClickHouse conversion function with single argument like toInt64(x).
+func (p *Parser) parseCastFunction(ident *Identifier, tp string) Expression {
+	call := &CallExpression{Token: p.curToken, Function: ident}
+	if call.Arguments = p.parseExpressionList(RPAREN); call.Arguments == nil || len(call.Arguments) != 1 {
+		return call
+	}
+
+	return &CastExpression{Token: ident.Token, Value: call.Arguments[0], Type: tp, Syntax: CastTypeFunction}
+}
+
+// parseInfixCastExpression parse PostgreSQL type conversion like: x::int.
+func (p *Parser) parseInfixCastExpression(left Expression) Expression {
+	exp := &CastExpression{Token: p.curToken, Value: left, Syntax: CastOperator}
+	p.nextToken()
+
+	if exp.Type = p.parseTypeName(false); exp.Type == "" {
+		return nil
+	}
+
+	return exp
+}
+
+// parseIntervalLiteral parse INTERVAL '1' DAY, INTERVAL 1 DAY or INTERVAL '1 day'.
+func (p *Parser) parseIntervalLiteral() Expression {
+	exp := &IntervalLiteral{Token: p.curToken}
+	p.nextToken()
+
+	if exp.Value = p.parseExpression(PREFIX); exp.Value == nil {
+		return nil
+	}
+
+	if unit, ok := intervalUnits[strings.ToLower(p.peekToken.Literal)]; ok && p.peekTokenIs(IDENT) {
+		p.nextToken()
+		exp.Unit = unit
+	}
+
+	return exp
+}
+
+// parseTypeName parse data type like: Int64, Nullable(String), DECIMAL(10, 2), int[].
+// Inside CAST the name may consist of several words like: double precision.
+func (p *Parser) parseTypeName(multiword bool) string {
+	if !isWord(p.curToken) {
+		p.addError(fmt.Sprintf("expected type name, got %s instead", p.curToken.Type))
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(p.curToken.Literal)
+
+	for {
+		switch {
+		case p.peekTokenIs(LPAREN):
+			p.nextToken()
+
+			if !p.parseTypeArguments(&sb) {
+				return ""
+			}
+		case p.peekTokenIs(LBRACKET):
+			p.nextToken()
+
+			if !p.expectPeek(RBRACKET) {
+				return ""
+			}
+			sb.WriteString("[]")
+		case multiword && isWord(p.peekToken):
+			p.nextToken()
+			sb.WriteString(" " + p.curToken.Literal)
+		default:
+			return sb.String()
+		}
+	}
+}
+
+// parseTypeArguments parse arguments of the type like: (10, 2), (String), ('UTC').
+func (p *Parser) parseTypeArguments(sb *strings.Builder) bool {
+	sb.WriteString("(")
+
+	for !p.peekTokenIs(RPAREN) {
+		p.nextToken()
+
+		switch {
+		case p.curTokenIs(COMMA):
+			sb.WriteString(", ")
+			continue
+		case p.curTokenIs(STRING):
+			sb.WriteString("'" + p.curToken.Literal + "'")
+			continue
+		case p.curTokenIs(INT):
+			sb.WriteString(p.curToken.Literal)
+			continue
+		}
+
+		if name := p.parseTypeName(true); name != "" {
+			sb.WriteString(name)
+			continue
+		}
+
+		return false
+	}
+
+	p.nextToken()
+	sb.WriteString(")")
+
+	return true
+}
+
+// isWord the token is an identifier or a keyword.
+func isWord(tok Token) bool {
+	return tok.Literal != "" && isLetter(tok.Literal[0]) && tok.Type != STRING
+}
@@ -0,0 +1,106 @@
+package sqlcmp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Span the position of statement in the source script, Start and End are byte offsets, End is exclusive.
+type Span struct {
+	Start int
+	End   int
+}
+
+// ParsedStatement the result of parsing of one statement of the script.
+// Statement is nil if the statement was not parsed, with WithRecovery option it is the partial statement.
+// Errors holds errors of this statement only.
+type ParsedStatement struct {
+	Statement Statement
+	Span      Span
+	Errors    []string
+}
+
+// Source returns the text of the statement in the script.
+func (ps ParsedStatement) Source(sql string) string {
+	return sql[ps.Span.Start:ps.Span.End]
+}
+
+// ParseScript parse semicolon separated statements, each statement is parsed on its own,
+// so an error in one statement does not affect the others. Empty statements are skipped.
+func ParseScript(sql string, opts ...Option) []ParsedStatement {
+	spans := splitScript(sql, opts)
+	result := make([]ParsedStatement, 0, len(spans))
+
+	for _, span := range spans {
+		p := NewParser(NewLexer(sql[span.Start:span.End], opts...), opts...)
+
+		result = append(result, p.parseScriptStatement(span))
+	}
+
+	return result
+}
+
+// parseScriptStatement parse SELECT or INSERT statement which takes all input of the parser.
+func (p *Parser) parseScriptStatement(span Span) (ps ParsedStatement) {
+	ps.Span = span
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.addError(fmt.Sprintf("%s: %v", ErrInternal, r))
+			ps = ParsedStatement{Span: span, Errors: p.Errors()}
+		}
+	}()
+
+	stmt := p.parseSQLStatement()
+	if stmt != nil && !p.curTokenIs(EOF) {
+		p.addError(fmt.Sprintf("unexpected %s at the end of query", p.curToken.Literal))
+	}
+
+	if ps.Errors = p.Errors(); len(ps.Errors) == 0 || (p.config.recovery && stmt != nil) {
+		ps.Statement = stmt
+	}
+
+	return ps
+}
+
+// splitScript returns spans of not empty statements of the script, semicolons inside of strings
+// and quoted identifiers do not split statements.
+func splitScript(sql string, opts []Option) []Span {
+	var spans []Span
+
+	l := NewLexer(sql, opts...)
+	start := 0
+
+	for {
+		tok := l.NextToken()
+		if tok.Type != SEMICOLON && tok.Type != EOF {
+			continue
+		}
+
+		end := len(sql)
+		if tok.Type == SEMICOLON {
+			end = l.position - 1
+		}
+
+		if span, ok := trimSpan(sql, start, end); ok {
+			spans = append(spans, span)
+		}
+
+		if tok.Type == EOF {
+			return spans
+		}
+		start = l.position
+	}
+}
+
+// trimSpan trims white spaces around the statement, false if the statement is empty.
+func trimSpan(sql string, start, end int) (Span, bool) {
+	text := sql[start:end]
+
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	start += len(text) - len(trimmed)
+	end = start + len(strings.TrimRightFunc(trimmed, unicode.IsSpace))
+
+	return Span{Start: start, End: end}, start != end
+}
@@ -0,0 +1,72 @@
+package sqlcmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScript(t *testing.T) {
+	t.Parallel()
+
+	sql := "select a from t where s = 'x;y';\n" +
+		"select * from t as 1;;\n" +
+		"  insert into t (a) values (1)  ;\n" +
+		"update t set a = 1;\n" +
+		"select 1)"
+
+	res := ParseScript(sql)
+	require.Len(t, res, 5)
+
+	tests := []struct {
+		source string
+		query  string
+		errors bool
+	}{
+		{source: "select a from t where s = 'x;y'", query: "SELECT a FROM t WHERE (s = x;y);"},
+		{source: "select * from t as 1", errors: true},
+		{source: "insert into t (a) values (1)", query: "INSERT INTO t (a) VALUES (1);"},
+		{source: "update t set a = 1", errors: true},
+		{source: "select 1)", errors: true},
+	}
+
+	for i, tt := range tests {
+		require.Equal(t, tt.source, res[i].Source(sql))
+
+		if tt.errors {
+			require.NotEmptyf(t, res[i].Errors, "statement: %s", tt.source)
+			require.Nil(t, res[i].Statement)
+
+			continue
+		}
+
+		require.Empty(t, res[i].Errors)
+		require.Equal(t, tt.query, res[i].Statement.String())
+	}
+
+	require.Equal(t, Span{Start: 0, End: 31}, res[0].Span)
+	require.Empty(t, ParseScript(" ; ;\n"))
+}
+
+func TestParseScriptDialect(t *testing.T) {
+	t.Parallel()
+
+	res := ParseScript("select $$a;b$$; select \"c;d\" from t", WithDialect(DialectPostgreSQL))
+	require.Len(t, res, 2)
+	require.Empty(t, res[0].Errors)
+	require.Equal(t, "SELECT c;d FROM t;", res[1].Statement.String())
+
+	res = ParseScript("select `a;b` from t; select 1", WithDialect(DialectMySQL))
+	require.Len(t, res, 2)
+	require.Equal(t, "SELECT a;b FROM t;", res[0].Statement.String())
+}
+
+func TestParseScriptRecovery(t *testing.T) {
+	t.Parallel()
+
+	res := ParseScript("select a from t group a; select 1", WithRecovery())
+	require.Len(t, res, 2)
+	require.NotEmpty(t, res[0].Errors)
+	require.Equal(t, "SELECT a FROM t;", res[0].Statement.String())
+	require.Empty(t, res[1].Errors)
+}
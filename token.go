@@ -8,18 +8,23 @@ const (
 
 	// List of Identifiers.
 
-	IDENT TokenType = "IDENT" // default TokenType like: add, foobar, x, y, ...
-	INT   TokenType = "INT"
+	IDENT       TokenType = "IDENT" // default TokenType like: add, foobar, x, y, ...
+	INT         TokenType = "INT"
+	FLOAT       TokenType = "FLOAT"
+	PLACEHOLDER TokenType = "PLACEHOLDER" // bind parameter like: ?, $1, :name
+	QIDENT      TokenType = "QIDENT"      // quoted identifier like: "order" in PostgreSQL, `order` in MySQL
 
 	// List of delimiters.
 
-	COMMA     TokenType = ","
-	SEMICOLON TokenType = ";"
-	LPAREN    TokenType = "("
-	RPAREN    TokenType = ")"
-	LBRACE    TokenType = "{"
-	RBRACE    TokenType = "}"
-	DOT       TokenType = "."
+	COMMA       TokenType = ","
+	SEMICOLON   TokenType = ";"
+	LPAREN      TokenType = "("
+	RPAREN      TokenType = ")"
+	LBRACE      TokenType = "{"
+	RBRACE      TokenType = "}"
+	DOT         TokenType = "."
+	DOUBLECOLON TokenType = "::"
+	ARROW       TokenType = "->"
 
 	// List of keywords.
 
@@ -58,6 +63,103 @@ const (
 	SQLNot     TokenType = "NOT"
 	SQLIn      TokenType = "IN"
 	SQLBetween TokenType = "BETWEEN"
+	SQLILike   TokenType = "ILIKE"
+	SQLRLike   TokenType = "RLIKE"
+	SQLRegexp  TokenType = "REGEXP"
+	SQLSimilar TokenType = "SIMILAR"
+	SQLIs      TokenType = "IS"
+	SQLOffset  TokenType = "OFFSET"
+	SQLFetch   TokenType = "FETCH"
+	SQLAll     TokenType = "ALL"
+	SQLExists  TokenType = "EXISTS"
+	SQLOver    TokenType = "OVER"
+	SQLWindow  TokenType = "WINDOW"
+
+	SQLPrewhere TokenType = "PREWHERE" // ClickHouse
+
+	// List of SQL soft keywords, they are lexed as IDENT and can be used as names.
+
+	SQLTo    TokenType = "TO"
+	SQLFirst TokenType = "FIRST"
+	SQLNext  TokenType = "NEXT"
+	SQLRows  TokenType = "ROWS"
+	SQLRow   TokenType = "ROW"
+	SQLOnly  TokenType = "ONLY"
+	SQLAny   TokenType = "ANY"
+	SQLSome  TokenType = "SOME"
+
+	SQLPartition TokenType = "PARTITION"
+	SQLRange     TokenType = "RANGE"
+	SQLGroups    TokenType = "GROUPS"
+	SQLUnbounded TokenType = "UNBOUNDED"
+	SQLPreceding TokenType = "PRECEDING"
+	SQLFollowing TokenType = "FOLLOWING"
+	SQLCurrent   TokenType = "CURRENT"
+	SQLCast      TokenType = "CAST"
+	SQLConvert   TokenType = "CONVERT"
+	SQLExtract   TokenType = "EXTRACT"
+	SQLInterval  TokenType = "INTERVAL"
+	SQLUsing     TokenType = "USING"
+	SQLFull      TokenType = "FULL"
+	SQLNatural   TokenType = "NATURAL"
+	SQLLateral   TokenType = "LATERAL"
+	SQLGlobal    TokenType = "GLOBAL"
+	SQLAsof      TokenType = "ASOF"
+	SQLSemi      TokenType = "SEMI"
+	SQLAnti      TokenType = "ANTI"
+	SQLNulls     TokenType = "NULLS"
+	SQLLast      TokenType = "LAST"
+	SQLCollate   TokenType = "COLLATE"
+	SQLWith      TokenType = "WITH"
+	SQLRollup    TokenType = "ROLLUP"
+	SQLCube      TokenType = "CUBE"
+	SQLGrouping  TokenType = "GROUPING"
+	SQLSets      TokenType = "SETS"
+	SQLDistinct  TokenType = "DISTINCT"
+	SQLFinal     TokenType = "FINAL"
+	SQLSample    TokenType = "SAMPLE"
+	SQLArray     TokenType = "ARRAY"
+	SQLTotals    TokenType = "TOTALS"
+	SQLSettings  TokenType = "SETTINGS"
+	SQLFormat    TokenType = "FORMAT"
+
+	SQLInsert     TokenType = "INSERT"
+	SQLInto       TokenType = "INTO"
+	SQLValues     TokenType = "VALUES"
+	SQLDefault    TokenType = "DEFAULT"
+	SQLReturning  TokenType = "RETURNING"
+	SQLConflict   TokenType = "CONFLICT"
+	SQLDo         TokenType = "DO"
+	SQLNothing    TokenType = "NOTHING"
+	SQLUpdate     TokenType = "UPDATE"
+	SQLConstraint TokenType = "CONSTRAINT"
+
+	SQLReplace   TokenType = "REPLACE"
+	SQLIgnore    TokenType = "IGNORE"
+	SQLDuplicate TokenType = "DUPLICATE"
+	SQLKey       TokenType = "KEY"
+	SQLUse       TokenType = "USE"
+	SQLForce     TokenType = "FORCE"
+	SQLIndex     TokenType = "INDEX"
+	SQLFor       TokenType = "FOR"
+	SQLLock      TokenType = "LOCK"
+	SQLShare     TokenType = "SHARE"
+	SQLMode      TokenType = "MODE"
+	SQLOf        TokenType = "OF"
+	SQLNowait    TokenType = "NOWAIT"
+	SQLSkip      TokenType = "SKIP"
+	SQLLocked    TokenType = "LOCKED"
+
+	// List of negated SQL operators.
+
+	SQLNotLike      TokenType = "NOT LIKE"
+	SQLNotILike     TokenType = "NOT ILIKE"
+	SQLNotRLike     TokenType = "NOT RLIKE"
+	SQLNotRegexp    TokenType = "NOT REGEXP"
+	SQLSimilarTo    TokenType = "SIMILAR TO"
+	SQLNotSimilarTo TokenType = "NOT SIMILAR TO"
+	SQLIsNot        TokenType = "IS NOT"
+	SQLGroupingSets TokenType = "GROUPING SETS"
 
 	// List of allow operators.
 
@@ -80,6 +182,24 @@ const (
 	COLON       TokenType = ":"
 	BinaryOr    TokenType = "|"
 	BinaryAnd   TokenType = "&"
+	PERCENT     TokenType = "%"
+	CARET       TokenType = "^"  // exponent, bitwise XOR in MySQL
+	TILDE       TokenType = "~"  // bitwise NOT
+	CONCAT      TokenType = "||" // string concatenation, logical OR in MySQL
+	ShiftLeft   TokenType = "<<"
+	ShiftRight  TokenType = ">>"
+	NullSafeEq  TokenType = "<=>" // MySQL NULL-safe equal
+
+	// List of PostgreSQL operators.
+
+	LongArrow     TokenType = "->>"
+	HashArrow     TokenType = "#>"
+	HashLongArrow TokenType = "#>>"
+	JSONContains  TokenType = "@>"
+	JSONContained TokenType = "<@"
+	JSONExists    TokenType = "?" // also bind parameter in prefix position
+	JSONExistsAny TokenType = "?|"
+	JSONExistsAll TokenType = "?&"
 )
 
 type TokenType string
@@ -131,8 +251,174 @@ var keywords = map[string]TokenType{
 	"not":     SQLNot,
 	"in":      SQLIn,
 	"between": SQLBetween,
-	// fix clickhouse - todo move to real case.
-	"prewhere": SQLWhere,
+	"is":      SQLIs,
+
+	"prewhere": SQLPrewhere,
+}
+
+// softKeywords these words have a special meaning only in some positions of a query.
+var softKeywords = map[string]TokenType{
+	"to":    SQLTo,
+	"first": SQLFirst,
+	"next":  SQLNext,
+	"rows":  SQLRows,
+	"row":   SQLRow,
+	"only":  SQLOnly,
+	"any":   SQLAny,
+	"some":  SQLSome,
+
+	"offset": SQLOffset,
+	"fetch":  SQLFetch,
+	"all":    SQLAll,
+
+	"ilike":   SQLILike,
+	"rlike":   SQLRLike,
+	"regexp":  SQLRegexp,
+	"similar": SQLSimilar,
+	"exists":  SQLExists,
+	"over":    SQLOver,
+	"window":  SQLWindow,
+
+	"partition": SQLPartition,
+	"range":     SQLRange,
+	"groups":    SQLGroups,
+	"unbounded": SQLUnbounded,
+	"preceding": SQLPreceding,
+	"following": SQLFollowing,
+	"current":   SQLCurrent,
+	"cast":      SQLCast,
+	"convert":   SQLConvert,
+	"extract":   SQLExtract,
+	"interval":  SQLInterval,
+	"using":     SQLUsing,
+	"full":      SQLFull,
+	"natural":   SQLNatural,
+	"lateral":   SQLLateral,
+	"global":    SQLGlobal,
+	"asof":      SQLAsof,
+	"semi":      SQLSemi,
+	"anti":      SQLAnti,
+	"nulls":     SQLNulls,
+	"last":      SQLLast,
+	"collate":   SQLCollate,
+	"with":      SQLWith,
+	"rollup":    SQLRollup,
+	"cube":      SQLCube,
+	"grouping":  SQLGrouping,
+	"sets":      SQLSets,
+	"distinct":  SQLDistinct,
+	"final":     SQLFinal,
+	"sample":    SQLSample,
+	"array":     SQLArray,
+	"totals":    SQLTotals,
+	"settings":  SQLSettings,
+	"format":    SQLFormat,
+
+	"insert":     SQLInsert,
+	"into":       SQLInto,
+	"values":     SQLValues,
+	"default":    SQLDefault,
+	"returning":  SQLReturning,
+	"conflict":   SQLConflict,
+	"do":         SQLDo,
+	"nothing":    SQLNothing,
+	"update":     SQLUpdate,
+	"constraint": SQLConstraint,
+
+	"replace":   SQLReplace,
+	"ignore":    SQLIgnore,
+	"duplicate": SQLDuplicate,
+	"key":       SQLKey,
+	"use":       SQLUse,
+	"force":     SQLForce,
+	"index":     SQLIndex,
+	"for":       SQLFor,
+	"lock":      SQLLock,
+	"share":     SQLShare,
+	"mode":      SQLMode,
+	"of":        SQLOf,
+	"nowait":    SQLNowait,
+	"skip":      SQLSkip,
+	"locked":    SQLLocked,
+}
+
+// columnAliasStops soft keywords which can start the next part of the query after a column, they are never taken
+// as an implicit alias of the column like: SELECT a OFFSET 1, while other soft keywords are like: SELECT a key.
+var columnAliasStops = map[TokenType]bool{
+	SQLWindow:    true,
+	SQLOffset:    true,
+	SQLFetch:     true,
+	SQLSettings:  true,
+	SQLFormat:    true,
+	SQLFor:       true,
+	SQLLock:      true,
+	SQLReturning: true,
+	SQLArray:     true, // ARRAY JOIN after ARRAY JOIN
+	SQLFull:      true,
+	SQLNatural:   true,
+	SQLGlobal:    true,
+	SQLAny:       true,
+	SQLAll:       true,
+	SQLAsof:      true,
+	SQLSemi:      true,
+	SQLAnti:      true,
+}
+
+// tableAliasStops soft keywords which can follow a table in addition to columnAliasStops like: FROM t FINAL,
+// JOIN t USING (id), FROM t USE INDEX (i), INSERT INTO t VALUES.
+var tableAliasStops = map[TokenType]bool{
+	SQLFinal:   true,
+	SQLSample:  true,
+	SQLUsing:   true,
+	SQLUse:     true,
+	SQLForce:   true,
+	SQLIgnore:  true,
+	SQLValues:  true,
+	SQLDefault: true,
+}
+
+// typedLiterals types which can precede string literal like: DATE '2023-09-27'.
+var typedLiterals = map[string]string{
+	"date":      "DATE",
+	"time":      "TIME",
+	"timestamp": "TIMESTAMP",
+	"datetime":  "DATETIME",
+}
+
+// intervalUnits units of INTERVAL literal.
+var intervalUnits = map[string]string{
+	"microsecond": "MICROSECOND", "microseconds": "MICROSECOND",
+	"millisecond": "MILLISECOND", "milliseconds": "MILLISECOND",
+	"second": "SECOND", "seconds": "SECOND",
+	"minute": "MINUTE", "minutes": "MINUTE",
+	"hour": "HOUR", "hours": "HOUR",
+	"day": "DAY", "days": "DAY",
+	"week": "WEEK", "weeks": "WEEK",
+	"month": "MONTH", "months": "MONTH",
+	"quarter": "QUARTER", "quarters": "QUARTER",
+	"year": "YEAR", "years": "YEAR",
+}
+
+// selectModifiers MySQL modifiers of SELECT like: SELECT SQL_CALC_FOUND_ROWS * FROM t.
+var selectModifiers = map[string]string{
+	"high_priority":       "HIGH_PRIORITY",
+	"straight_join":       "STRAIGHT_JOIN",
+	"sql_small_result":    "SQL_SMALL_RESULT",
+	"sql_big_result":      "SQL_BIG_RESULT",
+	"sql_buffer_result":   "SQL_BUFFER_RESULT",
+	"sql_cache":           "SQL_CACHE",
+	"sql_no_cache":        "SQL_NO_CACHE",
+	"sql_calc_found_rows": "SQL_CALC_FOUND_ROWS",
+}
+
+// castFunctions ClickHouse type conversion functions and their target types.
+var castFunctions = map[string]string{
+	"toint8": "Int8", "toint16": "Int16", "toint32": "Int32", "toint64": "Int64", "toint128": "Int128", "toint256": "Int256",
+	"touint8": "UInt8", "touint16": "UInt16", "touint32": "UInt32", "touint64": "UInt64", "touint128": "UInt128",
+	"touint256": "UInt256",
+	"tofloat32": "Float32", "tofloat64": "Float64",
+	"todate": "Date", "todate32": "Date32", "todatetime": "DateTime",
+	"tostring": "String", "touuid": "UUID", "toipv4": "IPv4", "toipv6": "IPv6", "tobool": "Bool",
 }
 
 // LookupIdent converts string to TokenType.
@@ -147,3 +433,12 @@ func LookupIdent(ident string) TokenType {
 
 	return IDENT
 }
+
+// LookupSoftKeyword converts IDENT literal to soft keyword TokenType or returns IDENT.
+func LookupSoftKeyword(ident string) TokenType {
+	if tok, ok := softKeywords[strings.ToLower(ident)]; ok {
+		return tok
+	}
+
+	return IDENT
+}
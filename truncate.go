@@ -0,0 +1,189 @@
+package sqlcmp
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrTruncated the query was cut off, the hash is created for the complete prefix of the query.
+var ErrTruncated = errors.New("truncated query")
+
+// truncationAttempts how many times the last token of the truncated query is dropped to get the query
+// which can be parsed.
+const truncationAttempts = 16
+
+// endTokens tokens which can end a complete query.
+var endTokens = map[TokenType]bool{
+	IDENT:       true,
+	QIDENT:      true,
+	INT:         true,
+	FLOAT:       true,
+	STRING:      true,
+	PLACEHOLDER: true,
+	RPAREN:      true,
+	RBRACKET:    true,
+	ASTERISK:    true,
+	TRUE:        true,
+	FALSE:       true,
+	SQLAsc:      true,
+	SQLDesc:     true,
+}
+
+// boundaryTokens tokens which start the next condition, list item or clause, the truncated query is cut before them,
+// so the prefix does not end inside of a condition like: a BETWEEN 1 AND.
+var boundaryTokens = map[TokenType]bool{
+	COMMA:       true,
+	SEMICOLON:   true,
+	SQLAnd:      true,
+	SQLOr:       true,
+	SQLFrom:     true,
+	SQLPrewhere: true,
+	SQLWhere:    true,
+	SQLGroup:    true,
+	SQLWindow:   true,
+	SQLOrder:    true,
+	SQLLimit:    true,
+	SQLJoin:     true,
+	SQLInner:    true,
+	SQLLeft:     true,
+	SQLRight:    true,
+	SQLCross:    true,
+	SQLOn:       true,
+	SQLOffset:   true,
+	SQLFetch:    true,
+	SQLSettings: true,
+	SQLFormat:   true,
+}
+
+// closingTokens closing brackets by the opening ones.
+var closingTokens = map[TokenType]string{
+	LPAREN:   ")",
+	LBRACKET: "]",
+}
+
+// offsetToken the token of the query with the offset of its end.
+type offsetToken struct {
+	Token
+	end     int
+	closing string // the quote which closes the unterminated string
+}
+
+// parseTruncated parse the complete prefix of the query which was cut off, the failed parser of the query tells
+// that the query ends inside of a string or the first error is found at the end of the query.
+// False if the query does not look truncated or the prefix can not be parsed.
+func parseTruncated(failed *Parser, sql string, opts []Option) (*Parser, Statement, bool) {
+	if !failed.l.unterminated && !failed.eofError {
+		return nil, nil, false
+	}
+
+	all, ok := truncatedTokens(sql, opts)
+	if !ok {
+		return nil, nil, false
+	}
+
+	tokens := all
+
+	for i := 0; i < truncationAttempts; i++ {
+		for len(tokens) != 0 && (!endTokens[tokens[len(tokens)-1].Type] || !cutAtBoundary(all, len(tokens))) {
+			tokens = tokens[:len(tokens)-1]
+		}
+
+		if len(tokens) == 0 {
+			return nil, nil, false
+		}
+
+		p := NewParser(NewLexer(completePrefix(sql, tokens), opts...), opts...)
+		if stmt := p.parseSQLStatement(); len(p.Errors()) == 0 {
+			return p, stmt, true
+		}
+
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	return nil, nil, false
+}
+
+// cutAtBoundary checks that the prefix of n tokens ends with the complete condition, list item or clause:
+// nothing is cut off or the cut off part starts with the next one.
+func cutAtBoundary(tokens []offsetToken, n int) bool {
+	if n == len(tokens) {
+		return true
+	}
+
+	tok := tokens[n]
+	if tok.Type == IDENT {
+		return boundaryTokens[LookupSoftKeyword(tok.Literal)]
+	}
+
+	return boundaryTokens[tok.Type]
+}
+
+// truncatedTokens returns tokens of the query, false if the query ends with a token which can end a query
+// and has no unclosed strings and brackets.
+func truncatedTokens(sql string, opts []Option) ([]offsetToken, bool) {
+	var (
+		tokens []offsetToken
+		depth  int
+	)
+
+	l := NewLexer(sql, opts...)
+
+	for tok := l.NextToken(); tok.Type != EOF; tok = l.NextToken() {
+		switch tok.Type {
+		case LPAREN, LBRACKET:
+			depth++
+		case RPAREN, RBRACKET:
+			depth--
+		}
+
+		tokens = append(tokens, offsetToken{Token: tok, end: l.position})
+	}
+
+	if l.unterminated {
+		last := &tokens[len(tokens)-1]
+
+		start := 0
+		if len(tokens) > 1 {
+			start = tokens[len(tokens)-2].end
+		}
+
+		// the opening quote like ', " or $tag$ closes the string too
+		last.end = len(sql)
+		last.closing = strings.TrimSpace(sql[start : len(sql)-len(last.Literal)])
+
+		return tokens, true
+	}
+
+	if len(tokens) == 0 {
+		return nil, false
+	}
+
+	return tokens, depth > 0 || !endTokens[tokens[len(tokens)-1].Type]
+}
+
+// completePrefix returns the query up to the last token with closed strings and brackets.
+func completePrefix(sql string, tokens []offsetToken) string {
+	var closing []string
+
+	for _, tok := range tokens {
+		switch tok.Type {
+		case LPAREN, LBRACKET:
+			closing = append(closing, closingTokens[tok.Type])
+		case RPAREN, RBRACKET:
+			if len(closing) != 0 {
+				closing = closing[:len(closing)-1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(sql[:tokens[len(tokens)-1].end])
+	sb.WriteString(tokens[len(tokens)-1].closing)
+
+	for i := len(closing) - 1; i >= 0; i-- {
+		sb.WriteString(closing[i])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,72 @@
+package sqlcmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTruncated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		expectedQuery string
+	}{
+		{
+			input:         "select a from t where a in (1, 2, 3",
+			expectedQuery: "SELECT a FROM t WHERE a IN (1, 2, 3);",
+		},
+		{
+			input:         "select a from t where a in (1, 2) and b = 'abc",
+			expectedQuery: "SELECT a FROM t WHERE (a IN (1, 2) AND (b = abc));",
+		},
+		{
+			input:         "select a from (select b from s where c in (1, ",
+			expectedQuery: "SELECT a FROM (SELECT b FROM s WHERE c IN (1));",
+		},
+		{
+			input:         "select a from t where x = 1 and y between 1 and",
+			expectedQuery: "SELECT a FROM t WHERE (x = 1);",
+		},
+		{
+			input:         "select a from t where x between 1 and 2 and y like",
+			expectedQuery: "SELECT a FROM t WHERE (x BETWEEN 1 AND 2);",
+		},
+		{
+			input:         "select a, sum(b) from t where a = 1 order by a, b desc, c +",
+			expectedQuery: "SELECT a, sum(b) FROM t WHERE (a = 1) ORDER BY a, b DESC;",
+		},
+		{
+			input:         "select a from t where a = 1 group by",
+			expectedQuery: "SELECT a FROM t WHERE (a = 1);",
+		},
+		{
+			input:         "select a from t where a = $tag$it's",
+			expectedQuery: "SELECT a FROM t WHERE (a = it's);",
+		},
+	}
+
+	for _, tt := range tests {
+		opts := []Option{WithDialect(DialectPostgreSQL)}
+
+		p, stmt, ok := parseTruncated(testFailedParser(tt.input, opts), tt.input, opts)
+		require.Truef(t, ok, "input: %s", tt.input)
+		require.Empty(t, p.Errors())
+		require.Equal(t, tt.expectedQuery, stmt.String())
+	}
+
+	for _, input := range []string{
+		"", "select a from t where a = 1", "select a from t group x", "select a from t where a = = 1 and",
+	} {
+		_, _, ok := parseTruncated(testFailedParser(input, nil), input, nil)
+		require.Falsef(t, ok, "input: %s", input)
+	}
+}
+
+func testFailedParser(input string, opts []Option) *Parser {
+	p := NewParser(NewLexer(input, opts...), opts...)
+	p.parseSQLStatement()
+
+	return p
+}
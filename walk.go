@@ -0,0 +1,280 @@
+package sqlcmp
+
+// Visitor the Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses an AST in depth-first order: It starts by calling v.Visit(node); node must not be nil.
+// If the visitor w returned by v.Visit(node) is not nil, Walk is invoked recursively with visitor w
+// for each of the not nil children of node, followed by a call of w.Visit(nil).
+// Children are visited in the order they appear in the query.
+//
+//nolint:funlen,gocyclo,cyclop
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	// Statements.
+
+	case *Program:
+		walkStatementList(v, n.Statements)
+	case *LetStatement:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		walkExpression(v, n.Value)
+	case *ReturnStatement:
+		walkExpression(v, n.ReturnValue)
+	case *ExpressionStatement:
+		walkExpression(v, n.Expression)
+	case *BlockStatement:
+		walkStatementList(v, n.Statements)
+	case *SQLSelectStatement:
+		walkExpressionList(v, n.DistinctOn)
+		walkExpressionList(v, n.SQLSelectColumns)
+		joins := 0
+		for i, from := range n.From {
+			for ; i != 0 && joins < len(n.Join) && joinAfter(n.Join[joins], len(n.From)) <= i; joins++ {
+				walkExpression(v, n.Join[joins])
+			}
+			walkExpression(v, from)
+		}
+		walkExpression(v, n.Sample)
+		walkExpression(v, n.SampleOffset)
+		walkExpressionList(v, n.ArrayJoin)
+		walkExpressionList(v, n.Join[joins:])
+		walkExpressionList(v, n.Prewhere)
+		walkExpressionList(v, n.Cond)
+		walkExpressionList(v, n.Group)
+		walkExpressionList(v, n.Window)
+		walkExpressionList(v, n.Order)
+		if n.LimitBy != nil {
+			Walk(v, n.LimitBy)
+		}
+		walkExpression(v, n.Limit)
+		walkExpression(v, n.Offset)
+		if n.Lock != nil {
+			Walk(v, n.Lock)
+		}
+		walkExpressionList(v, n.Settings)
+		for _, bad := range n.Bad {
+			if bad != nil {
+				Walk(v, bad)
+			}
+		}
+	case *SQLInsertStatement:
+		walkExpression(v, n.Table)
+		for _, row := range n.Values {
+			walkExpressionList(v, row)
+		}
+		if n.Select != nil {
+			Walk(v, n.Select)
+		}
+		if n.OnConflict != nil {
+			Walk(v, n.OnConflict)
+		}
+		if n.OnDuplicate != nil {
+			Walk(v, n.OnDuplicate)
+		}
+		walkExpressionList(v, n.Returning)
+
+	// Clauses.
+
+	case *SQLColumnExp:
+		walkExpression(v, n.Value)
+	case *SQLTableExp:
+		walkExpression(v, n.Table)
+		for _, hint := range n.Hints {
+			if hint != nil {
+				Walk(v, hint)
+			}
+		}
+	case *TableFunction:
+		walkExpressionList(v, n.Arguments)
+	case *SQLJoinExp:
+		walkExpression(v, n.Table)
+		walkExpressionList(v, n.Cond)
+	case *ArrayJoinExp:
+		walkExpressionList(v, n.Items)
+	case *SQLOrderExp:
+		walkExpression(v, n.Value)
+	case *GroupingExp:
+		for _, set := range n.Sets {
+			walkExpressionList(v, set)
+		}
+	case *SQLWindowExp:
+		if n.Spec != nil {
+			Walk(v, n.Spec)
+		}
+	case *LimitByExp:
+		walkExpression(v, n.Limit)
+		walkExpression(v, n.Offset)
+		walkExpressionList(v, n.Columns)
+	case *OnConflictExp:
+		walkExpressionList(v, n.Target)
+		walkExpressionList(v, n.Set)
+		walkExpressionList(v, n.Cond)
+	case *OnDuplicateKeyExp:
+		walkExpressionList(v, n.Set)
+
+	// Expressions.
+
+	case *SQLCondition:
+		walkExpression(v, n.Expression)
+	case *PrefixExpression:
+		walkExpression(v, n.Right)
+	case *InfixExpression:
+		walkExpression(v, n.Left)
+		walkExpression(v, n.Right)
+	case *BetweenExpression:
+		walkExpression(v, n.Column)
+		walkExpression(v, n.From)
+		walkExpression(v, n.To)
+	case *InExpression:
+		walkExpression(v, n.Column)
+		walkExpressionList(v, n.Arguments)
+	case *TupleExpression:
+		walkExpressionList(v, n.Elements)
+	case *ArrayLiteral:
+		walkExpressionList(v, n.Elements)
+	case *IndexExpression:
+		walkExpression(v, n.Left)
+		walkExpression(v, n.Index)
+	case *DotExpression:
+		walkExpression(v, n.Left)
+		walkExpression(v, n.Right)
+	case *LambdaExpression:
+		walkExpression(v, n.Body)
+	case *AliasExpression:
+		walkExpression(v, n.Value)
+	case *CallExpression:
+		walkExpression(v, n.Function)
+		walkExpressionList(v, n.Arguments)
+		if n.Window != nil {
+			Walk(v, n.Window)
+		}
+	case *WindowSpec:
+		walkExpressionList(v, n.PartitionBy)
+		walkExpressionList(v, n.OrderBy)
+		if n.Frame != nil {
+			Walk(v, n.Frame)
+		}
+	case *WindowFrame:
+		if n.Start != nil {
+			Walk(v, n.Start)
+		}
+		if n.End != nil {
+			Walk(v, n.End)
+		}
+	case *FrameBound:
+		walkExpression(v, n.Offset)
+	case *SQLSubSelectExpression:
+		if n.Select != nil {
+			Walk(v, n.Select)
+		}
+	case *ExistsExpression:
+		if n.Select != nil {
+			Walk(v, n.Select)
+		}
+	case *QuantifiedExpression:
+		if n.Select != nil {
+			Walk(v, n.Select)
+		}
+	case *CastExpression:
+		walkExpression(v, n.Value)
+	case *ExtractExpression:
+		walkExpression(v, n.From)
+	case *IntervalLiteral:
+		walkExpression(v, n.Value)
+	case *IfExpression:
+		walkExpression(v, n.Condition)
+		if n.Consequence != nil {
+			Walk(v, n.Consequence)
+		}
+		if n.Alternative != nil {
+			Walk(v, n.Alternative)
+		}
+	case *FunctionLiteral:
+		for _, param := range n.Parameters {
+			if param != nil {
+				Walk(v, param)
+			}
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *SelectLiteral:
+		walkExpressionList(v, n.Columns)
+		if n.From != nil {
+			Walk(v, n.From)
+		}
+		for _, where := range n.Where {
+			if where != nil {
+				Walk(v, where)
+			}
+		}
+		if n.GroupBy != nil {
+			Walk(v, n.GroupBy)
+		}
+		if n.OrderBy != nil {
+			Walk(v, n.OrderBy)
+		}
+
+	default:
+		// Identifier, QualifiedName, IntegerLiteral, FloatLiteral, StringLiteral, Boolean, Placeholder,
+		// TypedLiteral, IndexHintExp, LockExp and BadExpression have no children.
+	}
+
+	v.Visit(nil)
+}
+
+func walkExpression(v Visitor, exp Expression) {
+	if !isNil(exp) {
+		Walk(v, exp)
+	}
+}
+
+func walkExpressionList(v Visitor, list []Expression) {
+	for _, exp := range list {
+		walkExpression(v, exp)
+	}
+}
+
+func walkStatementList(v Visitor, list []Statement) {
+	for _, stmt := range list {
+		if !isNil(stmt) {
+			Walk(v, stmt)
+		}
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: It starts by calling f(node); node must not be nil.
+// If f returns true, Inspect invokes f recursively for each of the not nil children of node,
+// followed by a call of f(nil).
+//
+// For example, to collect names of all tables of the query including sub queries and joins:
+//
+//	sqlcmp.Inspect(stmt, func(n sqlcmp.Node) bool {
+//		if t, ok := n.(*sqlcmp.SQLTableExp); ok {
+//			tables = append(tables, t.Table.String())
+//		}
+//		return true
+//	})
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
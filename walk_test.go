@@ -0,0 +1,182 @@
+package sqlcmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	t.Parallel()
+
+	tables := func(n Node) (string, bool) {
+		if table, ok := n.(*SQLTableExp); ok {
+			return exprString(table.Table), true
+		}
+
+		return "", false
+	}
+
+	names := func(n Node) (string, bool) {
+		switch n := n.(type) {
+		case *Identifier, *QualifiedName:
+			return n.String(), true
+		}
+
+		return "", false
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		dialect Dialect
+		collect func(Node) (string, bool)
+		out     []string
+	}{
+		{
+			name: "tables of joins and sub queries",
+			input: "select a, (select max(b) from t5) from t1 join (select * from t2) as d on d.id = t1.id " +
+				"where b in (select c from t3) and exists (select 1 from t4)",
+			collect: tables,
+			out:     []string{"t5", "t1", "(SELECT * FROM t2)", "t2", "t3", "t4"},
+		},
+		{
+			name: "names of calls, windows and predicates",
+			input: "select count(x), sum(y + 1) over (partition by z order by w rows between v preceding and current row) " +
+				"from t where q between 1 and r order by s limit 1 by u",
+			collect: names,
+			out:     []string{"count", "x", "sum", "y", "z", "w", "v", "t", "q", "r", "s", "u"},
+		},
+		{
+			name:  "names and lock in order of the query",
+			input: "select a from t1 join t2 on b = c, t3 array join d for update settings e = 1",
+			collect: func(n Node) (string, bool) {
+				if lock, ok := n.(*LockExp); ok {
+					return lock.String(), true
+				}
+
+				return names(n)
+			},
+			out: []string{"a", "t1", "t2", "b", "c", "t3", "d", "FOR UPDATE", "e"},
+		},
+		{
+			name:    "names of insert",
+			input:   "insert into t (a, b) values (c, 1) on conflict (a) do update set b = excluded.b where t.d > e returning f",
+			dialect: DialectPostgreSQL,
+			collect: names,
+			out:     []string{"t", "c", "a", "b", "excluded.b", "t.d", "e", "f"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewParser(NewLexer(tt.input, WithDialect(tt.dialect)), WithDialect(tt.dialect))
+			stmt := p.parseSQLStatement()
+			checkParserErrors(t, p)
+
+			var out []string
+
+			Inspect(stmt, func(n Node) bool {
+				if s, ok := tt.collect(n); ok {
+					out = append(out, s)
+				}
+
+				return true
+			})
+
+			require.Equal(t, tt.out, out)
+		})
+	}
+}
+
+// countVisitor counts entered and left nodes, it does not enter sub queries.
+type countVisitor struct {
+	entered, left int
+}
+
+func (c *countVisitor) Visit(node Node) Visitor {
+	switch node.(type) {
+	case nil:
+		c.left++
+
+		return nil
+	case *SQLSubSelectExpression:
+		return nil
+	}
+
+	c.entered++
+
+	return c
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	p := NewParser(NewLexer("select a from t where b in (select c from s where d = 1) and e = 2"))
+	stmt := p.parseSQLStatement()
+	checkParserErrors(t, p)
+
+	c := &countVisitor{}
+	Walk(c, stmt)
+
+	require.Equal(t, c.entered, c.left)
+
+	var names []string
+
+	Inspect(stmt, func(n Node) bool {
+		if _, ok := n.(*SQLSubSelectExpression); ok {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *Identifier, *QualifiedName:
+			names = append(names, n.String())
+		}
+
+		return true
+	})
+
+	require.Equal(t, []string{"a", "t", "b", "e"}, names)
+}
+
+func TestWalkNilChildren(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		in   Node
+	}{
+		{
+			name: "typed nil children",
+			in: &InfixExpression{
+				Left:     (*InfixExpression)(nil),
+				Operator: ASSIGN,
+				Right:    &ExistsExpression{Select: (*SQLSubSelectExpression)(nil)},
+			},
+		},
+		{
+			name: "typed nil statement",
+			in:   &Program{Statements: []Statement{(*ExpressionStatement)(nil)}},
+		},
+		{
+			name: "typed nil column",
+			in:   &SQLSelectStatement{SQLSelectColumns: []Expression{(*SQLColumnExp)(nil)}, From: []Expression{nil}},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &countVisitor{}
+			require.NotPanics(t, func() { Walk(c, tc.in) })
+			require.Equal(t, c.entered, c.left)
+		})
+	}
+}